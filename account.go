@@ -8,56 +8,104 @@ import (
 	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 
-	"github.com/google/uuid"
+	"github.com/tehsphinx/form3/bic"
+	"github.com/tehsphinx/form3/iban"
+	"github.com/tehsphinx/form3/iso"
+	"github.com/tehsphinx/form3/modulus"
 )
 
-const accountsPath = "/v1/organisation/accounts"
+const (
+	accountsPath = "/v1/organisation/accounts"
+	// countryKosovo is accepted as account country: it has IBANs and BICs although it is not assigned by ISO 3166-1.
+	countryKosovo = "XK"
+)
 
 // client side account validation errors
 var (
-	ErrInvalidCountry      = errors.New("country should match '^[A-Z]{2}$'")
-	ErrInvalidBaseCurrency = errors.New("baseCurrency should match '^[A-Z]{3}$'")
-	ErrInvalidBankID       = errors.New("bankID should match '^[A-Z0-9]{0,16}$'")
-	ErrInvalidBankIDCode   = errors.New("bankIDCode should match '^[A-Z]{0,16}$'")
-	ErrInvalidBIC          = errors.New("bic should match '^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$'")
-	ErrInvalidAccClass     = errors.New("accountClassification should be one of [Personal Business]")
+	ErrInvalidCountry       = errors.New("country should be an ISO 3166-1 alpha-2 country code")
+	ErrInvalidBaseCurrency  = errors.New("baseCurrency should be an ISO 4217 currency code")
+	ErrInvalidBankID        = errors.New("bankID should match '^[A-Z0-9]{0,16}$'")
+	ErrInvalidBankIDCode    = errors.New("bankIDCode should match '^[A-Z]{0,16}$'")
+	ErrInvalidBIC           = errors.New("bic should match '^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$'")
+	ErrInvalidAccClass      = errors.New("accountClassification should be one of [Personal Business]")
+	ErrInvalidAccountNumber = errors.New("accountNumber does not match the format of the country")
+	ErrInvalidIBAN          = errors.New("iban is invalid")
+	ErrModulusCheck         = errors.New("bankID and accountNumber failed the modulus check")
+	ErrUnknownBIC           = errors.New("bic is not listed in the bic directory")
 )
 
 // Account holds account attributes.
 type Account struct {
 	baseAttr
 
-	Country                 string   `json:"country"`
-	BaseCurrency            string   `json:"base_currency,omitempty"`
-	AccountNumber           string   `json:"account_number,omitempty"`
-	BankID                  string   `json:"bank_id,omitempty"`
-	BankIDCode              string   `json:"bank_id_code,omitempty"`
-	BIC                     string   `json:"bic,omitempty"`
-	IBAN                    string   `json:"iban,omitempty"`
-	Name                    []string `json:"name,omitempty"`
-	AlternativeNames        []string `json:"alternative_names,omitempty"`
-	AccountClassification   string   `json:"account_classification,omitempty"`
-	SecondaryIdentification string   `json:"secondary_identification,omitempty"`
-	Status                  string   `json:"status,omitempty"`
-	JointAccount            bool     `json:"joint_account,omitempty"`
-	AccountMatchingOptOut   bool     `json:"account_matching_opt_out,omitempty"`
-	Switched                bool     `json:"switched,omitempty"`
-}
-
-// CreateAccount creates a new banking account.
-func (s *Client) CreateAccount(ctx context.Context, orgID string, data *Account) (*Account, error) {
+	Country                    string                      `json:"country"`
+	BaseCurrency               string                      `json:"base_currency,omitempty"`
+	AccountNumber              string                      `json:"account_number,omitempty"`
+	BankID                     string                      `json:"bank_id,omitempty"`
+	BankIDCode                 string                      `json:"bank_id_code,omitempty"`
+	BIC                        string                      `json:"bic,omitempty"`
+	IBAN                       string                      `json:"iban,omitempty"`
+	CustomerID                 string                      `json:"customer_id,omitempty"`
+	Name                       []string                    `json:"name,omitempty"`
+	AlternativeNames           []string                    `json:"alternative_names,omitempty"`
+	AccountClassification      AccountClassification       `json:"account_classification,omitempty"`
+	SecondaryIdentification    string                      `json:"secondary_identification,omitempty"`
+	Status                     AccountStatus               `json:"status,omitempty"`
+	StatusReason               string                      `json:"status_reason,omitempty"`
+	JointAccount               bool                        `json:"joint_account,omitempty"`
+	AccountMatchingOptOut      bool                        `json:"account_matching_opt_out,omitempty"`
+	Switched                   bool                        `json:"switched,omitempty"`
+	ProcessingService          string                      `json:"processing_service,omitempty"`
+	UserDefinedInformation     string                      `json:"user_defined_information,omitempty"`
+	AcceptanceQualifier        string                      `json:"acceptance_qualifier,omitempty"`
+	ReferenceMask              string                      `json:"reference_mask,omitempty"`
+	ValidationType             string                      `json:"validation_type,omitempty"`
+	NameMatchingStatus         NameMatchingStatus          `json:"name_matching_status,omitempty"`
+	PrivateIdentification      *PrivateIdentification      `json:"private_identification,omitempty"`
+	OrganisationIdentification *OrganisationIdentification `json:"organisation_identification,omitempty"`
+}
+
+// PrivateIdentification identifies the account holder if it is a person.
+type PrivateIdentification struct {
+	BirthDate      string   `json:"birth_date,omitempty"`
+	BirthCountry   string   `json:"birth_country,omitempty"`
+	Identification string   `json:"identification,omitempty"`
+	Address        []string `json:"address,omitempty"`
+	City           string   `json:"city,omitempty"`
+	Country        string   `json:"country,omitempty"`
+}
+
+// OrganisationIdentification identifies the account holder if it is an organisation.
+type OrganisationIdentification struct {
+	Identification     string              `json:"identification,omitempty"`
+	RegistrationNumber string              `json:"registration_number,omitempty"`
+	Actors             []OrganisationActor `json:"actors,omitempty"`
+	Representative     *OrganisationActor  `json:"representative,omitempty"`
+	Address            []string            `json:"address,omitempty"`
+	City               string              `json:"city,omitempty"`
+	Country            string              `json:"country,omitempty"`
+}
+
+// OrganisationActor is a person acting on behalf of an organisation.
+type OrganisationActor struct {
+	Name      []string `json:"name,omitempty"`
+	BirthDate string   `json:"birth_date,omitempty"`
+	Residency string   `json:"residency,omitempty"`
+}
+
+// CreateAccount creates a new banking account. By default a random id is generated for the account.
+// Use WithID or WithDeterministicID to control the id, so a create can be safely repeated:
+// if an account with that id already exists in the organisation, it is fetched and returned instead.
+func (s *Client) CreateAccount(ctx context.Context, orgID string, data *Account,
+	options ...CreateOption) (*Account, error) {
 	if err := s.validateAccount(data); err != nil {
 		return nil, fmt.Errorf("invalid Account information provided: %w", err)
 	}
 
-	uid := uuid.NewString()
-
 	resp := &Account{}
-	// resp := buildResp(respAttr)
-	uri := s.buildURL(accountsPath, "", nil)
-	if err := s.request(ctx, uri, typeAccounts, withMethod(http.MethodPost), withOrgID(orgID),
-		withUID(uid), withReq(data), withResp(resp), withStatusOk(http.StatusCreated)); err != nil {
+	if err := s.create(ctx, accountsPath, typeAccounts, orgID, data, resp, options); err != nil {
 		return nil, err
 	}
 
@@ -75,18 +123,78 @@ func (s *Client) FetchAccount(ctx context.Context, uid string) (*Account, error)
 	return resp, nil
 }
 
-func (s *Client) buildURL(basePath, uid string, params url.Values) string {
-	if uid != "" {
-		basePath += "/" + uid
+// AccountPatch holds the account attributes to be changed by UpdateAccount. Only fields that
+// are set (non-nil) are sent to the server, all other attributes remain untouched.
+// Use the String and Bool helpers and the Ptr methods of the enums to set the pointer fields.
+type AccountPatch struct {
+	Country                    *string                     `json:"country,omitempty"`
+	BaseCurrency               *string                     `json:"base_currency,omitempty"`
+	AccountNumber              *string                     `json:"account_number,omitempty"`
+	BankID                     *string                     `json:"bank_id,omitempty"`
+	BankIDCode                 *string                     `json:"bank_id_code,omitempty"`
+	BIC                        *string                     `json:"bic,omitempty"`
+	IBAN                       *string                     `json:"iban,omitempty"`
+	CustomerID                 *string                     `json:"customer_id,omitempty"`
+	Name                       []string                    `json:"name,omitempty"`
+	AlternativeNames           []string                    `json:"alternative_names,omitempty"`
+	AccountClassification      *AccountClassification      `json:"account_classification,omitempty"`
+	SecondaryIdentification    *string                     `json:"secondary_identification,omitempty"`
+	Status                     *AccountStatus              `json:"status,omitempty"`
+	StatusReason               *string                     `json:"status_reason,omitempty"`
+	JointAccount               *bool                       `json:"joint_account,omitempty"`
+	AccountMatchingOptOut      *bool                       `json:"account_matching_opt_out,omitempty"`
+	Switched                   *bool                       `json:"switched,omitempty"`
+	ProcessingService          *string                     `json:"processing_service,omitempty"`
+	UserDefinedInformation     *string                     `json:"user_defined_information,omitempty"`
+	AcceptanceQualifier        *string                     `json:"acceptance_qualifier,omitempty"`
+	ReferenceMask              *string                     `json:"reference_mask,omitempty"`
+	ValidationType             *string                     `json:"validation_type,omitempty"`
+	NameMatchingStatus         *NameMatchingStatus         `json:"name_matching_status,omitempty"`
+	PrivateIdentification      *PrivateIdentification      `json:"private_identification,omitempty"`
+	OrganisationIdentification *OrganisationIdentification `json:"organisation_identification,omitempty"`
+}
+
+// UpdateAccount changes the attributes set in the patch of the account with given account id.
+// The version must match the current version of the account. If it does not, the account was
+// updated meanwhile and a ErrConflict is returned. The returned account holds the new version
+// and can be used for further updates. With WithStrictEnums values of the patch unknown to the client
+// are rejected with ErrUnknownEnumValue before sending.
+func (s *Client) UpdateAccount(ctx context.Context, uid string, version int, patch *AccountPatch) (*Account, error) {
+	if s.strictEnums {
+		if err := patch.checkEnums(); err != nil {
+			return nil, fmt.Errorf("invalid AccountPatch provided: %w", err)
+		}
 	}
 
+	resp := &Account{}
+	uri := s.buildURL(accountsPath, uid, nil)
+	if err := s.request(ctx, uri, typeAccounts, withMethod(http.MethodPatch), withUID(uid),
+		withVersion(version), withReq(patch), withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *Client) buildURL(basePath, uid string, params url.Values) string {
 	uri := &url.URL{}
-	uri.Path = basePath
+	uri.Path = resourcePath(basePath, uid)
 	uri.RawQuery = params.Encode()
 	return s.baseURL + uri.RequestURI()
 }
 
-// ListAccounts retrieves a list of accounts that can be filtered (not yet implemented) and has pagination.
+// resourcePath appends the non empty segments to the base path. It is used to build the path of
+// nested resources, e.g. resourcePath(paymentsPath, paymentID, submissionsPath).
+func resourcePath(basePath string, segments ...string) string {
+	for _, segment := range segments {
+		if segment != "" {
+			basePath += "/" + segment
+		}
+	}
+	return basePath
+}
+
+// ListAccounts retrieves a list of accounts. It can be filtered (e.g. WithFilterCountry) and has pagination.
 func (s *Client) ListAccounts(ctx context.Context, opts ...ListOption) ([]Account, error) {
 	params := url.Values{}
 	applyOptions(params, opts)
@@ -110,6 +218,71 @@ func (s *Client) ListAccounts(ctx context.Context, opts ...ListOption) ([]Accoun
 	return accounts, nil
 }
 
+// AccountIterator iterates over all accounts of a list call page by page. Pages are fetched from
+// the server on demand while iterating. Use it like this:
+//
+//	it := cl.IterAccounts(ctx, form3.WithPageSize(100))
+//	for it.Next() {
+//		account := it.Account()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type AccountIterator struct {
+	it *pageIterator
+}
+
+// Next advances the iterator to the next account. It returns false when there are no more accounts
+// or an error occurred. Check Err after Next returned false.
+func (s *AccountIterator) Next() bool {
+	return s.it.next()
+}
+
+// Account returns the current account of the iterator.
+func (s *AccountIterator) Account() *Account {
+	if s.it.current == nil {
+		return nil
+	}
+	return s.it.current.(*Account)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (s *AccountIterator) Err() error {
+	return s.it.err
+}
+
+// IterAccounts returns an iterator over all accounts. The list options define the first page to be fetched.
+// All following pages are fetched by following the `next` link provided by the server.
+func (s *Client) IterAccounts(ctx context.Context, opts ...ListOption) *AccountIterator {
+	params := url.Values{}
+	applyOptions(params, opts)
+
+	uri := s.buildURL(accountsPath, "", params)
+	return &AccountIterator{
+		it: newPageIterator(ctx, s, uri, typeAccounts, func() responseFiller {
+			return &Account{}
+		}),
+	}
+}
+
+// ListAllAccounts retrieves the accounts of all pages. To protect against loading unexpectedly large
+// amounts of data, a ErrLimitExceeded is returned if there are more than `limit` accounts.
+func (s *Client) ListAllAccounts(ctx context.Context, limit int, opts ...ListOption) ([]Account, error) {
+	var accounts []Account
+	it := s.IterAccounts(ctx, opts...)
+	for it.Next() {
+		if len(accounts) == limit {
+			return nil, fmt.Errorf("listing accounts failed: %w", ErrLimitExceeded)
+		}
+		accounts = append(accounts, *it.Account())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
 // DeleteAccount deletes the account with given account id. If the resource was not found a
 // ErrNotFound will be returned. A ErrConflict indicates the account was updated meanwhile.
 // Check for specific error types with e.g. `errors.Is(err, form3.ErrConflict)`
@@ -127,31 +300,136 @@ This catches problems without making a call. Best would be to import the validat
 from the server code, so there is no extra effort to keep it in sync.
 */
 
+// accountCheck is an additional check of an account run after the attribute validation.
+type accountCheck func(attr *Account) ValidationErrors
+
 // some client side validation. Does not need to be complete, but should never be stricter than server.
-func getValidateAccount() func(attr *Account) error {
-	countryRE := regexp.MustCompile("^[A-Z]{2}$")
-	currencyRE := regexp.MustCompile("^[A-Z]{3}$")
+func getValidateAccount(checks ...accountCheck) func(attr *Account) error {
 	bankIDRE := regexp.MustCompile("^[A-Z0-9]{0,16}$")
 	bankIDCodeRE := regexp.MustCompile("^[A-Z0-9]{0,16}$")
 	bicRE := regexp.MustCompile("^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$")
+	rules := countryRules()
+	territories := territoryCountries()
 
 	return func(attr *Account) error {
-		switch {
-		case !countryRE.MatchString(attr.Country):
-			return ErrInvalidCountry
-		case attr.BaseCurrency != "" && !currencyRE.MatchString(attr.BaseCurrency):
-			return ErrInvalidBaseCurrency
-		case attr.BankID != "" && !bankIDRE.MatchString(attr.BankID):
-			return ErrInvalidBankID
-		case attr.BankIDCode != "" && !bankIDCodeRE.MatchString(attr.BankIDCode):
-			return ErrInvalidBankIDCode
-		case attr.BIC != "" && !bicRE.MatchString(attr.BIC):
-			return ErrInvalidBIC
-		case attr.AccountClassification != "" && attr.AccountClassification != "Personal" &&
-			attr.AccountClassification != "Business":
-			return ErrInvalidAccClass
+		var errs ValidationErrors
+		if attr.Country != countryKosovo && !iso.IsCountry(attr.Country) {
+			errs.add(attrPath("Country", "country"), attr.Country, ErrInvalidCountry)
+		}
+		if attr.BaseCurrency != "" && !iso.IsCurrency(attr.BaseCurrency) {
+			errs.add(attrPath("BaseCurrency", "base_currency"), attr.BaseCurrency, ErrInvalidBaseCurrency)
+		}
+		if attr.BankID != "" && !bankIDRE.MatchString(attr.BankID) {
+			errs.add(attrPath("BankID", "bank_id"), attr.BankID, ErrInvalidBankID)
+		}
+		if attr.BankIDCode != "" && !bankIDCodeRE.MatchString(attr.BankIDCode) {
+			errs.add(attrPath("BankIDCode", "bank_id_code"), attr.BankIDCode, ErrInvalidBankIDCode)
+		}
+		if attr.BIC != "" {
+			if err := validateBIC(attr, bicRE, territories); err != nil {
+				errs.add(attrPath("BIC", "bic"), attr.BIC, err)
+			}
+		}
+		if attr.AccountClassification != "" && !attr.AccountClassification.IsValid() {
+			errs.add(attrPath("AccountClassification", "account_classification"),
+				string(attr.AccountClassification), ErrInvalidAccClass)
+		}
+
+		if rule, ok := rules[attr.Country]; ok {
+			errs = append(errs, rule.validate(attr)...)
+		}
+		if attr.IBAN != "" {
+			if err := validateIBAN(attr, territories); err != nil {
+				errs.add(attrPath("IBAN", "iban"), attr.IBAN, err)
+			}
+		}
+		for _, check := range checks {
+			errs = append(errs, check(attr)...)
+		}
+		return errs.err()
+	}
+}
+
+// modulusCheck checks sort code and account number of GB accounts with the modulus checker.
+// Accounts with an invalid format are left to the country rules.
+func modulusCheck(checker *modulus.Checker) accountCheck {
+	return func(attr *Account) ValidationErrors {
+		if attr.Country != "GB" || attr.BankID == "" || attr.AccountNumber == "" {
+			return nil
 		}
 
+		var errs ValidationErrors
+		if err := checker.Check(attr.BankID, attr.AccountNumber); errors.Is(err, modulus.ErrCheckFailed) {
+			errs.add(attrPath("AccountNumber", "account_number"), attr.AccountNumber, ErrModulusCheck)
+		}
+		return errs
+	}
+}
+
+// validateBIC checks the structure of the BIC and if it matches the country of the account. Territories
+// may use the BICs of other countries.
+func validateBIC(attr *Account, bicRE *regexp.Regexp, territories territories) error {
+	if !bicRE.MatchString(attr.BIC) {
+		return ErrInvalidBIC
+	}
+
+	code, err := bic.Parse(attr.BIC)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBIC, err)
+	}
+	if !territories.matches(attr.Country, code.Country) {
+		return fmt.Errorf("%w: country %s does not match the account country %s", ErrInvalidBIC, code.Country, attr.Country)
+	}
+	return nil
+}
+
+// ibanBankIDMatches compares the bank id of the IBAN with the bank id of the account. Italian accounts with
+// an account number have an 11 character bank id starting with the CIN check character of the BBAN.
+func ibanBankIDMatches(ib *iban.IBAN, bankID string) bool {
+	if bankID == ib.BankID {
+		return true
+	}
+	return ib.Country == "IT" && bankID == ib.BBAN[:1]+ib.BankID
+}
+
+// bicDirectoryCheck checks that the BIC of an account is listed in the directory.
+// Invalid BICs are left to the attribute validation.
+func bicDirectoryCheck(dir bic.Directory) accountCheck {
+	return func(attr *Account) ValidationErrors {
+		code, err := bic.Parse(attr.BIC)
+		if attr.BIC == "" || err != nil {
+			return nil
+		}
+
+		var errs ValidationErrors
+		if _, ok := dir.Lookup(code); !ok {
+			errs.add(attrPath("BIC", "bic"), attr.BIC, ErrUnknownBIC)
+		}
+		return errs
+	}
+}
+
+// validateIBAN checks the IBAN and if it matches the country, bank id and account number of the account.
+// Territories may use the IBANs of other countries. IBANs of countries missing in the registry of the
+// iban package are left to the server.
+func validateIBAN(attr *Account, territories territories) error {
+	ib, err := iban.Parse(attr.IBAN)
+	if errors.Is(err, iban.ErrInvalidCountry) {
 		return nil
 	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidIBAN, err)
+	}
+
+	switch {
+	case !territories.matches(attr.Country, ib.Country):
+		return fmt.Errorf("%w: country %s does not match the account country %s", ErrInvalidIBAN, ib.Country, attr.Country)
+	case attr.BankID != "" && ib.BankID != "" && !ibanBankIDMatches(ib, attr.BankID):
+		return fmt.Errorf("%w: bank id %s does not match the bank_id %s", ErrInvalidIBAN, ib.BankID, attr.BankID)
+	case attr.AccountNumber != "" && ib.AccountNumber != "" &&
+		strings.TrimLeft(ib.AccountNumber, "0") != strings.TrimLeft(attr.AccountNumber, "0"):
+		// the account numbers are left out of the message as errors are commonly logged
+		return fmt.Errorf("%w: account number does not match the account_number", ErrInvalidIBAN)
+	}
+	return nil
 }
@@ -8,24 +8,37 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/matryer/is"
 	"github.com/namsral/flag"
 	"github.com/tehsphinx/form3"
+	"github.com/tehsphinx/form3/form3test"
 )
 
 var endpoint string
 var debugEnabled bool
+var fakeEnabled bool
 
 const orgID = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
 
 func TestMain(m *testing.M) {
 	flag.StringVar(&endpoint, "endpoint", "http://localhost:8080", "test server endpoint url")
 	flag.BoolVar(&debugEnabled, "debug", false, "enable colored debug output")
+	flag.BoolVar(&fakeEnabled, "fake", false, "run against an in-memory fake server instead of the endpoint")
+	// only the environment variables (ENDPOINT, DEBUG, FAKE) can be used: arguments given to the test binary
+	// with `go test -args` are rejected by the flag parsing of the testing package.
 	flag.Parse()
 
-	cleanAccountsTable()
+	if !fakeEnabled {
+		cleanAccountsTable()
+		os.Exit(m.Run())
+	}
 
+	// the accountapi image of the docker-compose stack does not store names. Mimic that.
+	srv := form3test.NewServer(form3test.WithoutAttributes("name", "alternative_names"))
+	endpoint = srv.URL
 	code := m.Run()
+	srv.Close()
 	os.Exit(code)
 }
 
@@ -49,6 +62,9 @@ the test data would have to be without overlap. E.g the `List` call would need a
 other test data or its own server/database to run against.
 
 WARNING: the tests will clean the Account table first.
+
+Run the tests with FAKE=true to test against the in-memory fake server of the form3test package
+instead. That does not require the docker-compose stack.
 */
 
 var accountTests = []struct {
@@ -146,6 +162,44 @@ func TestClient_FetchAccount(t *testing.T) {
 	}
 }
 
+func TestClient_UpdateAccount(t *testing.T) {
+	if !fakeEnabled {
+		t.Skip("the accountapi image does not implement PATCH")
+	}
+
+	for i, tt := range accountTests {
+		i := i // scope variable
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			cl := getClient()
+			got, err := cl.UpdateAccount(ctx, tt.uid, tt.version, &form3.AccountPatch{
+				SecondaryIdentification: form3.String("Z9Y8X7W6"),
+			})
+
+			assert := is.New(t)
+			assert.NoErr(err)
+			assert.Equal(got.ID(), tt.uid)
+			assert.True(got.Version() > tt.version)
+
+			want := *tt.accountData
+			want.SecondaryIdentification = "Z9Y8X7W6"
+			assert.Equal(copyAccount(*got), &want)
+
+			// updating the outdated version again must fail
+			_, err = cl.UpdateAccount(ctx, tt.uid, tt.version, &form3.AccountPatch{
+				SecondaryIdentification: form3.String("A1B2C3D4"),
+			})
+			assert.True(errors.Is(err, form3.ErrConflict))
+
+			// save new state to test case for the other tests
+			accountTests[i].version = got.Version()
+			accountTests[i].accountData = &want
+		})
+	}
+}
+
 func TestClient_ListAccounts(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -235,6 +289,26 @@ func TestClient_ListAccounts(t *testing.T) {
 	}
 }
 
+func TestClient_IterAccounts(t *testing.T) {
+	assert := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	cl := getClient()
+	it := cl.IterAccounts(ctx, form3.WithPageSize(1))
+
+	var uids []string
+	for it.Next() {
+		uids = append(uids, it.Account().ID())
+	}
+	assert.NoErr(it.Err())
+	assert.Equal(uids, []string{accountTests[0].uid, accountTests[1].uid})
+
+	all, err := cl.ListAllAccounts(ctx, 10, form3.WithPageSize(1))
+	assert.NoErr(err)
+	assert.Equal(len(all), 2)
+}
+
 func TestClient_DeleteAccount(t *testing.T) {
 	for _, tt := range accountTests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -258,24 +332,75 @@ func TestClient_DeleteAccount(t *testing.T) {
 	}
 }
 
+func TestClient_CreateAccountIdempotent(t *testing.T) {
+	if !fakeEnabled {
+		t.Skip("the conflict handling is only tested against the fake server")
+	}
+
+	assert := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	namespace := uuid.MustParse("8c3f0ad3-3a59-4b54-9b0d-0d0f2e1a2b3c")
+	data := &form3.Account{
+		Country:      "GB",
+		BaseCurrency: "GBP",
+		BankID:       "400300",
+		BankIDCode:   "GBDSC",
+		BIC:          "NWBKGB22",
+	}
+
+	cl := getClient()
+	created, err := cl.CreateAccount(ctx, orgID, data, form3.WithDeterministicID(namespace, "customer-4711"))
+	assert.NoErr(err)
+
+	// creating the account a second time returns the existing account
+	again, err := cl.CreateAccount(ctx, orgID, data, form3.WithDeterministicID(namespace, "customer-4711"))
+	assert.NoErr(err)
+	assert.Equal(again.ID(), created.ID())
+	assert.Equal(again.Version(), created.Version())
+
+	// an explicit id works the same way
+	again, err = cl.CreateAccount(ctx, orgID, data, form3.WithID(created.ID()))
+	assert.NoErr(err)
+	assert.Equal(again.ID(), created.ID())
+
+	// a different organisation can't claim the existing account
+	_, err = cl.CreateAccount(ctx, "0d27e265-9605-4b4b-a0e5-3003ea9cc4dc", data, form3.WithID(created.ID()))
+	assert.True(errors.Is(err, form3.ErrConflict))
+
+	err = cl.DeleteAccount(ctx, created.ID(), created.Version())
+	assert.NoErr(err)
+}
+
 // use to get rid of private values for comparison
 func copyAccount(account form3.Account) *form3.Account {
 	return &form3.Account{
-		Country:                 account.Country,
-		BaseCurrency:            account.BaseCurrency,
-		AccountNumber:           account.AccountNumber,
-		BankID:                  account.BankID,
-		BankIDCode:              account.BankIDCode,
-		BIC:                     account.BIC,
-		IBAN:                    account.IBAN,
-		Name:                    account.Name,
-		AlternativeNames:        account.AlternativeNames,
-		AccountClassification:   account.AccountClassification,
-		JointAccount:            account.JointAccount,
-		AccountMatchingOptOut:   account.AccountMatchingOptOut,
-		SecondaryIdentification: account.SecondaryIdentification,
-		Switched:                account.Switched,
-		Status:                  account.Status,
+		Country:                    account.Country,
+		BaseCurrency:               account.BaseCurrency,
+		AccountNumber:              account.AccountNumber,
+		BankID:                     account.BankID,
+		BankIDCode:                 account.BankIDCode,
+		BIC:                        account.BIC,
+		IBAN:                       account.IBAN,
+		CustomerID:                 account.CustomerID,
+		Name:                       account.Name,
+		AlternativeNames:           account.AlternativeNames,
+		AccountClassification:      account.AccountClassification,
+		JointAccount:               account.JointAccount,
+		AccountMatchingOptOut:      account.AccountMatchingOptOut,
+		SecondaryIdentification:    account.SecondaryIdentification,
+		Switched:                   account.Switched,
+		Status:                     account.Status,
+		StatusReason:               account.StatusReason,
+		ProcessingService:          account.ProcessingService,
+		UserDefinedInformation:     account.UserDefinedInformation,
+		AcceptanceQualifier:        account.AcceptanceQualifier,
+		ReferenceMask:              account.ReferenceMask,
+		ValidationType:             account.ValidationType,
+		NameMatchingStatus:         account.NameMatchingStatus,
+		PrivateIdentification:      account.PrivateIdentification,
+		OrganisationIdentification: account.OrganisationIdentification,
 	}
 }
 
@@ -0,0 +1,73 @@
+package form3_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/tehsphinx/form3"
+	"github.com/tehsphinx/form3/form3test"
+)
+
+// TestClient_accountAttributes makes sure all account attributes survive a round trip to the API.
+// It uses its own fake server as the accountapi image of the docker-compose stack does not support all of them.
+func TestClient_accountAttributes(t *testing.T) {
+	assert := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	srv := form3test.NewServer()
+	defer srv.Close()
+
+	data := &form3.Account{
+		Country:                 "GB",
+		BaseCurrency:            "GBP",
+		AccountNumber:           "41426819",
+		BankID:                  "400300",
+		BankIDCode:              "GBDSC",
+		BIC:                     "NWBKGB22",
+		IBAN:                    "GB16NWBK40030041426819",
+		CustomerID:              "cust-1",
+		Name:                    []string{"Samantha Holder"},
+		AlternativeNames:        []string{"Sam Holder"},
+		AccountClassification:   "Business",
+		SecondaryIdentification: "A1B2C3D4",
+		JointAccount:            true,
+		Switched:                true,
+		ProcessingService:       "ABC Bank",
+		UserDefinedInformation:  "some info",
+		AcceptanceQualifier:     "same_day",
+		ReferenceMask:           "############",
+		ValidationType:          "card",
+		NameMatchingStatus:      "supported",
+		PrivateIdentification: &form3.PrivateIdentification{
+			BirthDate:      "2017-07-23",
+			BirthCountry:   "GB",
+			Identification: "13YH458762",
+			Address:        []string{"10 Avenue des Champs"},
+			City:           "London",
+			Country:        "GB",
+		},
+		OrganisationIdentification: &form3.OrganisationIdentification{
+			Identification:     "123654",
+			RegistrationNumber: "RN-1",
+			Actors: []form3.OrganisationActor{
+				{Name: []string{"Jeff Page"}, BirthDate: "1970-01-01", Residency: "GB"},
+			},
+			Representative: &form3.OrganisationActor{Name: []string{"Jane Page"}, Residency: "GB"},
+			Address:        []string{"10 Avenue des Champs"},
+			City:           "London",
+			Country:        "GB",
+		},
+	}
+
+	cl := form3.NewClient(srv.URL)
+	created, err := cl.CreateAccount(ctx, orgID, data)
+	assert.NoErr(err)
+	assert.Equal(copyAccount(*created), data)
+
+	fetched, err := cl.FetchAccount(ctx, created.ID())
+	assert.NoErr(err)
+	assert.Equal(copyAccount(*fetched), data)
+}
@@ -0,0 +1,209 @@
+package form3
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CountryRuleError is returned if an account violates a rule specific to its country.
+// Check for the violated field with e.g. `errors.Is(err, form3.ErrInvalidBankID)`.
+type CountryRuleError struct {
+	// Country of the account.
+	Country string
+	// Field is the json name of the invalid attribute.
+	Field string
+	// Reason describes the violated rule.
+	Reason string
+
+	// err holds the client side account validation error of the field (e.g. ErrInvalidBankID).
+	err error
+}
+
+// Error implements the error interface.
+func (s *CountryRuleError) Error() string {
+	return fmt.Sprintf("invalid %s for country %s: %s", s.Field, s.Country, s.Reason)
+}
+
+// Unwrap returns the validation error of the field (e.g. ErrInvalidBankID).
+func (s *CountryRuleError) Unwrap() error {
+	return s.err
+}
+
+// presence defines if an attribute is required, optional or not supported in a country.
+type presence int
+
+const (
+	optional presence = iota
+	required
+	notSupported
+)
+
+// fieldRule defines the rule for one attribute in a country.
+type fieldRule struct {
+	presence presence
+	format   *regexp.Regexp
+	// describes the format for error messages
+	desc string
+}
+
+// countryRule holds the account rules of a country as documented by the account API.
+type countryRule struct {
+	bankID        fieldRule
+	bankIDCode    string
+	bankIDCodeReq presence
+	bic           presence
+	accountNumber fieldRule
+	iban          presence
+}
+
+func field(p presence, format, desc string) fieldRule {
+	return fieldRule{presence: p, format: regexp.MustCompile(format), desc: desc}
+}
+
+// countryRules returns the rules for all countries with specific rules.
+func countryRules() map[string]countryRule {
+	return map[string]countryRule{
+		"GB": {
+			bankID: field(required, `^\d{6}$`, "6 digit sort code"), bankIDCode: "GBDSC", bankIDCodeReq: required,
+			bic: required, accountNumber: field(optional, `^\d{8}$`, "8 digits"),
+		},
+		"AU": {
+			bankID: field(optional, `^\d{6}$`, "6 digit BSB code"), bankIDCode: "AUBSB", bankIDCodeReq: required,
+			bic: required, accountNumber: field(optional, `^\d{6,10}$`, "6 to 10 digits"), iban: notSupported,
+		},
+		"BE": {
+			bankID: field(required, `^\d{3}$`, "3 digits"), bankIDCode: "BE", bankIDCodeReq: required,
+			accountNumber: field(optional, `^\d{7}$`, "7 digits"),
+		},
+		"CA": {
+			bankID: field(optional, `^0\d{8}$`, "9 digits starting with 0"), bankIDCode: "CACPA",
+			bic: required, accountNumber: field(optional, `^\d{7,12}$`, "7 to 12 digits"), iban: notSupported,
+		},
+		"FR": {
+			bankID: field(required, `^\d{10}$`, "10 digits of bank and branch code"), bankIDCode: "FR",
+			bankIDCodeReq: required, accountNumber: field(optional, `^[0-9A-Z]{11}$`, "11 characters"),
+		},
+		"DE": {
+			bankID: field(required, `^\d{8}$`, "8 digit Bankleitzahl"), bankIDCode: "DEBLZ", bankIDCodeReq: required,
+			accountNumber: field(optional, `^\d{7}$`, "7 digits"),
+		},
+		"GR": {
+			bankID: field(required, `^\d{7}$`, "7 digits"), bankIDCode: "GRBIC", bankIDCodeReq: required,
+			accountNumber: field(optional, `^\d{16}$`, "16 digits"),
+		},
+		"HK": {
+			bankID: field(optional, `^\d{3}$`, "3 digits"), bankIDCode: "HKNCC",
+			bic: required, accountNumber: field(optional, `^\d{9,12}$`, "9 to 12 digits"), iban: notSupported,
+		},
+		"IT": {
+			bankID: field(required, `^[0-9A-Z]{10,11}$`, "10 or 11 characters"), bankIDCode: "ITNCC",
+			bankIDCodeReq: required, accountNumber: field(optional, `^\d{12}$`, "12 digits"),
+		},
+		"LU": {
+			bankID: field(required, `^\d{3}$`, "3 digits"), bankIDCode: "LULUX", bankIDCodeReq: required,
+			accountNumber: field(optional, `^[0-9A-Z]{13}$`, "13 characters"),
+		},
+		"NL": {
+			bankID: fieldRule{presence: notSupported}, bankIDCodeReq: notSupported,
+			bic: required, accountNumber: field(optional, `^\d{10}$`, "10 digits"),
+		},
+		"PL": {
+			bankID: field(required, `^\d{8}$`, "8 digits"), bankIDCode: "PLKNR", bankIDCodeReq: required,
+			accountNumber: field(optional, `^\d{16}$`, "16 digits"),
+		},
+		"PT": {
+			bankID: field(required, `^\d{8}$`, "8 digits"), bankIDCode: "PTNCC", bankIDCodeReq: required,
+			accountNumber: field(optional, `^\d{11}$`, "11 digits"),
+		},
+		"ES": {
+			bankID: field(required, `^\d{8}$`, "8 digits"), bankIDCode: "ESNCC", bankIDCodeReq: required,
+			accountNumber: field(optional, `^\d{10}$`, "10 digits"),
+		},
+		"CH": {
+			bankID: field(required, `^\d{5}$`, "5 digits"), bankIDCode: "CHBCC", bankIDCodeReq: required,
+			accountNumber: field(optional, `^[0-9A-Z]{12}$`, "12 characters"),
+		},
+		"US": {
+			bankID: field(required, `^\d{9}$`, "9 digit ABA routing number"), bankIDCode: "USABA",
+			bankIDCodeReq: required, bic: required, accountNumber: field(optional, `^\d{6,17}$`, "6 to 17 digits"),
+			iban: notSupported,
+		},
+	}
+}
+
+// territories maps a country to the other countries whose BICs and IBANs its accounts may use.
+type territories map[string][]string
+
+// territoryCountries returns the territories using BICs or IBANs of another country, e.g. accounts in
+// Guernsey have GB IBANs.
+func territoryCountries() territories {
+	return territories{
+		"GG": {"GB"}, "JE": {"GB"}, "IM": {"GB"},
+		"GF": {"FR"}, "GP": {"FR"}, "MQ": {"FR"}, "RE": {"FR"}, "YT": {"FR"}, "PM": {"FR"}, "BL": {"FR"},
+		"MF": {"FR"}, "NC": {"FR"}, "PF": {"FR"}, "WF": {"FR"}, "TF": {"FR"}, "MC": {"FR"},
+		"AX": {"FI"}, "FO": {"DK"}, "GL": {"DK"}, "SJ": {"NO"},
+		"PR": {"US"}, "GU": {"US"}, "VI": {"US"}, "AS": {"US"}, "MP": {"US"},
+		"XK": {"RS", "AL"},
+	}
+}
+
+// matches checks if accounts in the account country may use BICs or IBANs of the given country.
+func (s territories) matches(accountCountry, country string) bool {
+	if accountCountry == country {
+		return true
+	}
+	for _, parent := range s[accountCountry] {
+		if parent == country {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the account against the rules of its country and returns all violations.
+func (s countryRule) validate(attr *Account) ValidationErrors {
+	var errs ValidationErrors
+	bankIDCodePath := attrPath("BankIDCode", "bank_id_code")
+	fail := func(path fieldPath, value, reason string, err error) {
+		jsonName := strings.TrimPrefix(path.path, attributesPath)
+		errs.add(path, value, &CountryRuleError{Country: attr.Country, Field: jsonName, Reason: reason, err: err})
+	}
+
+	if reason := s.bankID.validate(attr.BankID); reason != "" {
+		fail(attrPath("BankID", "bank_id"), attr.BankID, reason, ErrInvalidBankID)
+	}
+	switch {
+	case s.bankIDCodeReq == required && attr.BankIDCode == "":
+		fail(bankIDCodePath, attr.BankIDCode, "is required", ErrInvalidBankIDCode)
+	case s.bankIDCodeReq == notSupported && attr.BankIDCode != "":
+		fail(bankIDCodePath, attr.BankIDCode, "is not supported", ErrInvalidBankIDCode)
+	case s.bankIDCode != "" && attr.BankIDCode != "" && attr.BankIDCode != s.bankIDCode:
+		fail(bankIDCodePath, attr.BankIDCode, "must be "+s.bankIDCode, ErrInvalidBankIDCode)
+	}
+	if s.bic == required && attr.BIC == "" {
+		fail(attrPath("BIC", "bic"), attr.BIC, "is required", ErrInvalidBIC)
+	}
+	if reason := s.accountNumber.validate(attr.AccountNumber); reason != "" {
+		fail(attrPath("AccountNumber", "account_number"), attr.AccountNumber, reason, ErrInvalidAccountNumber)
+	}
+	if s.iban == notSupported && attr.IBAN != "" {
+		fail(attrPath("IBAN", "iban"), attr.IBAN, "is not supported", ErrInvalidIBAN)
+	}
+	return errs
+}
+
+// validate checks a value against the rule. Returns the reason if the value is invalid.
+func (s fieldRule) validate(value string) string {
+	switch {
+	case value == "" && s.presence == required:
+		return "is required"
+	case value == "":
+		return ""
+	case s.presence == notSupported:
+		return "is not supported"
+	case !s.format.MatchString(value):
+		return "must be " + s.desc
+	}
+	return ""
+}
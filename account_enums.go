@@ -0,0 +1,144 @@
+package form3
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownEnumValue is returned in strict mode (see WithStrictEnums) if the server responds with
+// a value of a closed value set unknown to the client.
+var ErrUnknownEnumValue = errors.New("unknown enum value")
+
+// AccountClassification classifies an account as personal or business account.
+type AccountClassification string
+
+// account classifications
+const (
+	AccountClassificationPersonal AccountClassification = "Personal"
+	AccountClassificationBusiness AccountClassification = "Business"
+)
+
+func accountClassifications() []AccountClassification {
+	return []AccountClassification{AccountClassificationPersonal, AccountClassificationBusiness}
+}
+
+// IsValid checks if the classification is one of the known classifications.
+func (s AccountClassification) IsValid() bool {
+	for _, value := range accountClassifications() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s AccountClassification) String() string {
+	return string(s)
+}
+
+// Ptr returns a pointer to the classification. Useful to set fields of a patch.
+func (s AccountClassification) Ptr() *AccountClassification {
+	return &s
+}
+
+// AccountStatus is the status of an account.
+type AccountStatus string
+
+// account statuses
+const (
+	AccountStatusPending   AccountStatus = "pending"
+	AccountStatusConfirmed AccountStatus = "confirmed"
+	AccountStatusFailed    AccountStatus = "failed"
+	AccountStatusClosed    AccountStatus = "closed"
+)
+
+func accountStatuses() []AccountStatus {
+	return []AccountStatus{AccountStatusPending, AccountStatusConfirmed, AccountStatusFailed, AccountStatusClosed}
+}
+
+// IsValid checks if the status is one of the known statuses.
+func (s AccountStatus) IsValid() bool {
+	for _, value := range accountStatuses() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s AccountStatus) String() string {
+	return string(s)
+}
+
+// Ptr returns a pointer to the status. Useful to set fields of a patch.
+func (s AccountStatus) Ptr() *AccountStatus {
+	return &s
+}
+
+// NameMatchingStatus defines if an account takes part in Confirmation of Payee name matching.
+type NameMatchingStatus string
+
+// name matching statuses
+const (
+	NameMatchingStatusSupported    NameMatchingStatus = "supported"
+	NameMatchingStatusSwitched     NameMatchingStatus = "switched"
+	NameMatchingStatusOptedOut     NameMatchingStatus = "opted_out"
+	NameMatchingStatusNotSupported NameMatchingStatus = "not_supported"
+)
+
+func nameMatchingStatuses() []NameMatchingStatus {
+	return []NameMatchingStatus{
+		NameMatchingStatusSupported, NameMatchingStatusSwitched,
+		NameMatchingStatusOptedOut, NameMatchingStatusNotSupported,
+	}
+}
+
+// IsValid checks if the name matching status is one of the known statuses.
+func (s NameMatchingStatus) IsValid() bool {
+	for _, value := range nameMatchingStatuses() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s NameMatchingStatus) String() string {
+	return string(s)
+}
+
+// Ptr returns a pointer to the status. Useful to set fields of a patch.
+func (s NameMatchingStatus) Ptr() *NameMatchingStatus {
+	return &s
+}
+
+// checkEnums implements the enumChecker interface. It is used in strict mode to reject responses
+// with unknown values. Empty values are not checked.
+func (s *Account) checkEnums() error {
+	switch {
+	case s.AccountClassification != "" && !s.AccountClassification.IsValid():
+		return fmt.Errorf("%w: account_classification %q", ErrUnknownEnumValue, s.AccountClassification)
+	case s.Status != "" && !s.Status.IsValid():
+		return fmt.Errorf("%w: status %q", ErrUnknownEnumValue, s.Status)
+	case s.NameMatchingStatus != "" && !s.NameMatchingStatus.IsValid():
+		return fmt.Errorf("%w: name_matching_status %q", ErrUnknownEnumValue, s.NameMatchingStatus)
+	}
+	return nil
+}
+
+// checkEnums checks the values set in the patch. It is used in strict mode to reject patches
+// with values unknown to the client before they are sent.
+func (s *AccountPatch) checkEnums() error {
+	switch {
+	case s.AccountClassification != nil && !s.AccountClassification.IsValid():
+		return fmt.Errorf("%w: account_classification %q", ErrUnknownEnumValue, *s.AccountClassification)
+	case s.Status != nil && !s.Status.IsValid():
+		return fmt.Errorf("%w: status %q", ErrUnknownEnumValue, *s.Status)
+	case s.NameMatchingStatus != nil && !s.NameMatchingStatus.IsValid():
+		return fmt.Errorf("%w: name_matching_status %q", ErrUnknownEnumValue, *s.NameMatchingStatus)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAccountEnums_IsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{ IsValid() bool }
+		want  bool
+	}{
+		{name: "personal", value: AccountClassificationPersonal, want: true},
+		{name: "business", value: AccountClassificationBusiness, want: true},
+		{name: "lower case classification", value: AccountClassification("personal"), want: false},
+		{name: "confirmed", value: AccountStatusConfirmed, want: true},
+		{name: "unknown status", value: AccountStatus("frozen"), want: false},
+		{name: "opted out", value: NameMatchingStatusOptedOut, want: true},
+		{name: "empty name matching status", value: NameMatchingStatus(""), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+			assert.Equal(tt.value.IsValid(), tt.want)
+		})
+	}
+}
+
+func TestClient_WithStrictEnums(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"type":"accounts","id":"some-id","attributes":{"country":"GB","status":"frozen"}}}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		options []ClientOption
+		wantErr error
+	}{
+		{name: "lenient by default"},
+		{name: "strict", options: []ClientOption{WithStrictEnums()}, wantErr: ErrUnknownEnumValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			cl := NewClient(srv.URL, tt.options...)
+			account, err := cl.FetchAccount(context.Background(), "some-id")
+			assert.True(errors.Is(err, tt.wantErr))
+			if err == nil {
+				assert.Equal(account.Status, AccountStatus("frozen"))
+			}
+		})
+	}
+}
+
+func TestClient_UpdateAccountStrictEnums(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"type":"accounts","id":"some-id","attributes":{"country":"GB"}}}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		options []ClientOption
+		patch   *AccountPatch
+		wantErr error
+	}{
+		{
+			name:    "known status",
+			options: []ClientOption{WithStrictEnums()},
+			patch:   &AccountPatch{Status: AccountStatusClosed.Ptr()},
+		},
+		{
+			name:    "unknown status",
+			options: []ClientOption{WithStrictEnums()},
+			patch:   &AccountPatch{Status: AccountStatus("frozen").Ptr()},
+			wantErr: ErrUnknownEnumValue,
+		},
+		{
+			name:    "unknown classification",
+			options: []ClientOption{WithStrictEnums()},
+			patch:   &AccountPatch{AccountClassification: AccountClassification("Private").Ptr()},
+			wantErr: ErrUnknownEnumValue,
+		},
+		{
+			name:  "unknown value lenient by default",
+			patch: &AccountPatch{NameMatchingStatus: NameMatchingStatus("paused").Ptr()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			cl := NewClient(srv.URL, tt.options...)
+			_, err := cl.UpdateAccount(context.Background(), "some-id", 0, tt.patch)
+			assert.True(errors.Is(err, tt.wantErr))
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package form3
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
+	"github.com/tehsphinx/form3/bic"
+	"github.com/tehsphinx/form3/modulus"
 )
 
 func Test_getValidateAccount(t *testing.T) {
@@ -19,6 +24,21 @@ func Test_getValidateAccount(t *testing.T) {
 			},
 			wantErr: ErrInvalidCountry,
 		},
+		{
+			name: "unknown country",
+			attr: &Account{
+				Country: "ZZ",
+			},
+			wantErr: ErrInvalidCountry,
+		},
+		{
+			name: "unknown currency",
+			attr: &Account{
+				Country:      "GB",
+				BaseCurrency: "XXQ",
+			},
+			wantErr: ErrInvalidBaseCurrency,
+		},
 		{
 			name: "invalid currency",
 			attr: &Account{
@@ -51,6 +71,38 @@ func Test_getValidateAccount(t *testing.T) {
 			},
 			wantErr: ErrInvalidBIC,
 		},
+		{
+			name: "BIC with unknown country",
+			attr: &Account{
+				Country: "GB",
+				BIC:     "NWBKZZ22",
+			},
+			wantErr: ErrInvalidBIC,
+		},
+		{
+			name: "BIC of other country",
+			attr: &Account{
+				Country: "DE",
+				BIC:     "NWBKGB22",
+			},
+			wantErr: ErrInvalidBIC,
+		},
+		{
+			name: "BIC of parent country",
+			attr: &Account{
+				Country: "GG",
+				BIC:     "NWBKGB22",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "XK account with RS BIC",
+			attr: &Account{
+				Country: "XK",
+				BIC:     "RZBSRSBG",
+			},
+			wantErr: nil,
+		},
 		{
 			name: "invalid account classification",
 			attr: &Account{
@@ -59,6 +111,101 @@ func Test_getValidateAccount(t *testing.T) {
 			},
 			wantErr: ErrInvalidAccClass,
 		},
+		{
+			name: "invalid iban check digits",
+			attr: &Account{
+				Country: "GB",
+				IBAN:    "GB17NWBK40030041426819",
+			},
+			wantErr: ErrInvalidIBAN,
+		},
+		{
+			name: "iban of parent country",
+			attr: &Account{
+				Country: "JE",
+				IBAN:    "GB16NWBK40030041426819",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "UA iban",
+			attr: &Account{
+				Country: "UA",
+				IBAN:    "UA213223130000026007233566001",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "iban of country missing in the registry is left to the server",
+			attr: &Account{
+				Country: "JP",
+				IBAN:    "JP4512345678901234",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "iban of other country",
+			attr: &Account{
+				Country: "DE",
+				IBAN:    "GB16NWBK40030041426819",
+			},
+			wantErr: ErrInvalidIBAN,
+		},
+		{
+			name: "iban does not match bank id",
+			attr: &Account{
+				Country:    "GB",
+				BankID:     "400301",
+				BankIDCode: "GBDSC",
+				BIC:        "NWBKGB22",
+				IBAN:       "GB16NWBK40030041426819",
+			},
+			wantErr: ErrInvalidIBAN,
+		},
+		{
+			name: "iban does not match account number",
+			attr: &Account{
+				Country:       "DE",
+				BankID:        "37040044",
+				BankIDCode:    "DEBLZ",
+				AccountNumber: "0532014",
+				IBAN:          "DE59370400440000532013",
+			},
+			wantErr: ErrInvalidIBAN,
+		},
+		{
+			name: "IT iban matching bank id with CIN",
+			attr: &Account{
+				Country:       "IT",
+				BankID:        "X0542811101",
+				BankIDCode:    "ITNCC",
+				AccountNumber: "000000123456",
+				IBAN:          "IT60X0542811101000000123456",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "IT iban with other CIN",
+			attr: &Account{
+				Country:       "IT",
+				BankID:        "Y0542811101",
+				BankIDCode:    "ITNCC",
+				AccountNumber: "000000123456",
+				IBAN:          "IT60X0542811101000000123456",
+			},
+			wantErr: ErrInvalidIBAN,
+		},
+		{
+			name: "iban matching padded account number",
+			attr: &Account{
+				Country:       "DE",
+				BankID:        "37040044",
+				BankIDCode:    "DEBLZ",
+				AccountNumber: "0532013",
+				IBAN:          "DE59370400440000532013",
+			},
+			wantErr: nil,
+		},
 		{
 			name: "valid account",
 			attr: &Account{
@@ -79,7 +226,321 @@ func Test_getValidateAccount(t *testing.T) {
 			assert := is.New(t)
 
 			err := validateAccount(tt.attr)
-			assert.Equal(err, tt.wantErr)
+			assert.True(errors.Is(err, tt.wantErr))
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+			}
+		})
+	}
+}
+
+func Test_getValidateAccountAllErrors(t *testing.T) {
+	assert := is.New(t)
+
+	err := getValidateAccount()(&Account{
+		Country:      "GB",
+		BaseCurrency: "GB",
+		BankID:       "40030",
+		BankIDCode:   "GBDSC",
+		BIC:          "43278r23",
+	})
+
+	var errs ValidationErrors
+	assert.True(errors.As(err, &errs))
+	assert.True(errors.Is(err, ErrInvalidBaseCurrency))
+	assert.True(errors.Is(err, ErrInvalidBankID))
+	assert.True(errors.Is(err, ErrInvalidBIC))
+	assert.True(!errors.Is(err, ErrInvalidCountry))
+
+	want := []ValidationError{
+		{Field: "BaseCurrency", Path: "data.attributes.base_currency", Value: "GB"},
+		{Field: "BIC", Path: "data.attributes.bic", Value: "43278r23"},
+		{Field: "BankID", Path: "data.attributes.bank_id", Value: "40030"},
+	}
+	assert.Equal(len(errs), len(want))
+	for i, w := range want {
+		assert.Equal(errs[i].Field, w.Field)
+		assert.Equal(errs[i].Path, w.Path)
+		assert.Equal(errs[i].Value, w.Value)
+		assert.True(errs[i].Rule != "")
+	}
+	assert.Equal(errs[2].Rule, "invalid bank_id for country GB: must be 6 digit sort code")
+	// the values are not part of the message
+	assert.Equal(errs[2].Error(), "data.attributes.bank_id: invalid bank_id for country GB: must be 6 digit sort code")
+	assert.True(!strings.Contains(err.Error(), "43278r23"))
+}
+
+func Test_modulusCheck(t *testing.T) {
+	checker, err := modulus.NewChecker(strings.NewReader(
+		"089000 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateAccount := getValidateAccount(modulusCheck(checker))
+
+	tests := []struct {
+		name    string
+		attr    *Account
+		wantErr error
+	}{
+		{
+			name:    "passes modulus check",
+			attr:    &Account{Country: "GB", BankID: "089999", BankIDCode: "GBDSC", BIC: "NWBKGB22", AccountNumber: "66374958"},
+			wantErr: nil,
+		},
+		{
+			name:    "fails modulus check",
+			attr:    &Account{Country: "GB", BankID: "089999", BankIDCode: "GBDSC", BIC: "NWBKGB22", AccountNumber: "66374959"},
+			wantErr: ErrModulusCheck,
+		},
+		{
+			name:    "without account number",
+			attr:    &Account{Country: "GB", BankID: "089999", BankIDCode: "GBDSC", BIC: "NWBKGB22"},
+			wantErr: nil,
+		},
+		{
+			name:    "other country",
+			attr:    &Account{Country: "DE", BankID: "08999966", BankIDCode: "DEBLZ", AccountNumber: "6637495"},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			err := validateAccount(tt.attr)
+			assert.True(errors.Is(err, tt.wantErr))
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+			}
+		})
+	}
+}
+
+func Test_bicDirectoryCheck(t *testing.T) {
+	dir, err := bic.ReadCSV(strings.NewReader("bic,name\nNWBKGB22,National Westminster Bank\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateAccount := getValidateAccount(bicDirectoryCheck(dir))
+
+	tests := []struct {
+		name    string
+		attr    *Account
+		wantErr error
+	}{
+		{
+			name:    "listed BIC",
+			attr:    &Account{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", BIC: "NWBKGB22"},
+			wantErr: nil,
+		},
+		{
+			name:    "unknown BIC",
+			attr:    &Account{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", BIC: "HBUKGB4B"},
+			wantErr: ErrUnknownBIC,
+		},
+		{
+			name:    "without BIC",
+			attr:    &Account{Country: "DE", BankID: "37040044", BankIDCode: "DEBLZ"},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			err := validateAccount(tt.attr)
+			assert.True(errors.Is(err, tt.wantErr))
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+			}
+		})
+	}
+}
+
+func Test_accountJSON(t *testing.T) {
+	assert := is.New(t)
+
+	account := &Account{
+		Country:                 "GB",
+		BaseCurrency:            "GBP",
+		AccountNumber:           "41426819",
+		BankID:                  "400300",
+		BankIDCode:              "GBDSC",
+		BIC:                     "NWBKGB22",
+		IBAN:                    "GB11NWBK40030041426819",
+		CustomerID:              "cust-1",
+		Name:                    []string{"Samantha Holder"},
+		AccountClassification:   "Personal",
+		Status:                  "closed",
+		StatusReason:            "unspecified",
+		ProcessingService:       "ABC Bank",
+		UserDefinedInformation:  "some info",
+		AcceptanceQualifier:     "same_day",
+		ReferenceMask:           "############",
+		ValidationType:          "card",
+		NameMatchingStatus:      "supported",
+		SecondaryIdentification: "A1B2C3D4",
+		PrivateIdentification: &PrivateIdentification{
+			BirthDate:      "2017-07-23",
+			BirthCountry:   "GB",
+			Identification: "13YH458762",
+			Address:        []string{"10 Avenue des Champs"},
+			City:           "London",
+			Country:        "GB",
+		},
+		OrganisationIdentification: &OrganisationIdentification{
+			Identification:     "123654",
+			RegistrationNumber: "RN-1",
+			Actors: []OrganisationActor{
+				{Name: []string{"Jeff Page"}, BirthDate: "1970-01-01", Residency: "GB"},
+			},
+			Representative: &OrganisationActor{Name: []string{"Jane Page"}, Residency: "GB"},
+			Address:        []string{"10 Avenue des Champs"},
+			City:           "London",
+			Country:        "GB",
+		},
+	}
+
+	want := `{"country":"GB","base_currency":"GBP","account_number":"41426819","bank_id":"400300",` +
+		`"bank_id_code":"GBDSC","bic":"NWBKGB22","iban":"GB11NWBK40030041426819","customer_id":"cust-1",` +
+		`"name":["Samantha Holder"],"account_classification":"Personal","secondary_identification":"A1B2C3D4",` +
+		`"status":"closed","status_reason":"unspecified","processing_service":"ABC Bank",` +
+		`"user_defined_information":"some info","acceptance_qualifier":"same_day",` +
+		`"reference_mask":"############","validation_type":"card","name_matching_status":"supported",` +
+		`"private_identification":{"birth_date":"2017-07-23","birth_country":"GB","identification":"13YH458762",` +
+		`"address":["10 Avenue des Champs"],"city":"London","country":"GB"},` +
+		`"organisation_identification":{"identification":"123654","registration_number":"RN-1",` +
+		`"actors":[{"name":["Jeff Page"],"birth_date":"1970-01-01","residency":"GB"}],` +
+		`"representative":{"name":["Jane Page"],"residency":"GB"},"address":["10 Avenue des Champs"],` +
+		`"city":"London","country":"GB"}}`
+
+	body, err := json.Marshal(account)
+	assert.NoErr(err)
+	assert.Equal(string(body), want)
+
+	got := &Account{}
+	assert.NoErr(json.Unmarshal(body, got))
+	assert.Equal(got, account)
+}
+
+func Test_countryRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		attr      *Account
+		wantErr   error
+		wantField string
+	}{
+		{
+			name:    "valid GB account",
+			attr:    &Account{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", BIC: "NWBKGB22", AccountNumber: "41426819"},
+			wantErr: nil,
+		},
+		{
+			name:      "GB sort code too short",
+			attr:      &Account{Country: "GB", BankID: "40030", BankIDCode: "GBDSC", BIC: "NWBKGB22"},
+			wantErr:   ErrInvalidBankID,
+			wantField: "bank_id",
+		},
+		{
+			name:      "GB with german bank id code",
+			attr:      &Account{Country: "GB", BankID: "400300", BankIDCode: "DEBLZ", BIC: "NWBKGB22"},
+			wantErr:   ErrInvalidBankIDCode,
+			wantField: "bank_id_code",
+		},
+		{
+			name:      "GB without BIC",
+			attr:      &Account{Country: "GB", BankID: "400300", BankIDCode: "GBDSC"},
+			wantErr:   ErrInvalidBIC,
+			wantField: "bic",
+		},
+		{
+			name: "GB account number too long",
+			attr: &Account{
+				Country: "GB", BankID: "400300", BankIDCode: "GBDSC", BIC: "NWBKGB22", AccountNumber: "414268190",
+			},
+			wantErr:   ErrInvalidAccountNumber,
+			wantField: "account_number",
+		},
+		{
+			name:    "valid DE account",
+			attr:    &Account{Country: "DE", BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "0532013"},
+			wantErr: nil,
+		},
+		{
+			name: "valid BE account",
+			attr: &Account{
+				Country: "BE", BankID: "539", BankIDCode: "BE", AccountNumber: "0075470", IBAN: "BE68539007547034",
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "BE with former bank id code",
+			attr:      &Account{Country: "BE", BankID: "539", BankIDCode: "BEBAC"},
+			wantErr:   ErrInvalidBankIDCode,
+			wantField: "bank_id_code",
+		},
+		{
+			name: "valid FR account",
+			attr: &Account{
+				Country: "FR", BankID: "2004101005", BankIDCode: "FR", AccountNumber: "0500013M026",
+				IBAN: "FR1420041010050500013M02606",
+			},
+			wantErr: nil,
+		},
+		{
+			name:      "FR bank id with letters",
+			attr:      &Account{Country: "FR", BankID: "20041A1005", BankIDCode: "FR"},
+			wantErr:   ErrInvalidBankID,
+			wantField: "bank_id",
+		},
+		{
+			name:      "FR account number too short",
+			attr:      &Account{Country: "FR", BankID: "2004101005", BankIDCode: "FR", AccountNumber: "0500013M02"},
+			wantErr:   ErrInvalidAccountNumber,
+			wantField: "account_number",
+		},
+		{
+			name:      "DE without bank id",
+			attr:      &Account{Country: "DE", BankIDCode: "DEBLZ"},
+			wantErr:   ErrInvalidBankID,
+			wantField: "bank_id",
+		},
+		{
+			name:      "NL with bank id",
+			attr:      &Account{Country: "NL", BankID: "1234", BIC: "ABNANL2A"},
+			wantErr:   ErrInvalidBankID,
+			wantField: "bank_id",
+		},
+		{
+			name:      "US with IBAN",
+			attr:      &Account{Country: "US", BankID: "021000021", BankIDCode: "USABA", BIC: "CHASUS33", IBAN: "US00"},
+			wantErr:   ErrInvalidIBAN,
+			wantField: "iban",
+		},
+		{
+			name:    "country without specific rules",
+			attr:    &Account{Country: "JP"},
+			wantErr: nil,
+		},
+	}
+
+	validateAccount := getValidateAccount()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			err := validateAccount(tt.attr)
+			assert.True(errors.Is(err, tt.wantErr))
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+				return
+			}
+
+			var ruleErr *CountryRuleError
+			assert.True(errors.As(err, &ruleErr))
+			assert.Equal(ruleErr.Country, tt.attr.Country)
+			assert.Equal(ruleErr.Field, tt.wantField)
 		})
 	}
 }
@@ -0,0 +1,67 @@
+package form3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const requestIDHeader = "X-Request-Id"
+
+// APIError is returned for every response of the server with an unexpected status code.
+// It carries the information the server provided about the failure. Check for specific
+// error types with e.g. `errors.Is(err, form3.ErrNotFound)` or get hold of the details
+// with `errors.As(err, &apiErr)`.
+type APIError struct {
+	// StatusCode is the http status code of the response.
+	StatusCode int
+	// Status is the http status text of the response, e.g. "400 Bad Request".
+	Status string
+	// Method is the http method of the failed request.
+	Method string
+	// URL is the url of the failed request. Sensitive filter values are redacted (see WithRedaction).
+	URL string
+	// RequestID is the request id the server assigned to the request (if provided).
+	RequestID string
+	// ErrorMessage is the error message the server returned in the response body.
+	ErrorMessage string `json:"error_message"`
+	// ErrorCode is the error code the server returned in the response body.
+	ErrorCode string `json:"error_code"`
+
+	// err holds one of the custom errors for http status codes (e.g. ErrNotFound).
+	err error
+}
+
+func newAPIError(resp *http.Response, body []byte, redaction RedactionRules) *APIError {
+	apiErr := &APIError{}
+	// the body is not guaranteed to hold an error message (or even json). Ignore it if it doesn't.
+	_ = json.Unmarshal(body, apiErr)
+
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.Status = resp.Status
+	apiErr.Method = resp.Request.Method
+	apiErr.URL = redaction.redactURL(resp.Request.URL.String())
+	apiErr.RequestID = resp.Header.Get(requestIDHeader)
+	apiErr.err = errFromStatusCode(resp.StatusCode)
+	return apiErr
+}
+
+// Error implements the error interface.
+func (s *APIError) Error() string {
+	msg := fmt.Sprintf("unexpected response status %d (%s) for %s %s", s.StatusCode, s.Status, s.Method, s.URL)
+	if s.ErrorMessage != "" {
+		msg += ": " + s.ErrorMessage
+	}
+	if s.ErrorCode != "" {
+		msg += " (error code " + s.ErrorCode + ")"
+	}
+	if s.RequestID != "" {
+		msg += " [request id " + s.RequestID + "]"
+	}
+	return msg
+}
+
+// Unwrap returns the custom error matching the status code (e.g. ErrNotFound) if there is one.
+func (s *APIError) Unwrap() error {
+	return s.err
+}
@@ -0,0 +1,84 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		requestID  string
+		want       *APIError
+		wantErr    error
+	}{
+		{
+			name:       "bad request with error message",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error_message":"validation failure","error_code":"a1b2c3"}`,
+			requestID:  "req-1",
+			want: &APIError{
+				StatusCode:   http.StatusBadRequest,
+				Status:       "400 Bad Request",
+				Method:       http.MethodGet,
+				RequestID:    "req-1",
+				ErrorMessage: "validation failure",
+				ErrorCode:    "a1b2c3",
+			},
+			wantErr: ErrBadRequest,
+		},
+		{
+			name:       "not found without body",
+			statusCode: http.StatusNotFound,
+			want: &APIError{
+				StatusCode: http.StatusNotFound,
+				Status:     "404 Not Found",
+				Method:     http.MethodGet,
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:       "server error with plain text body",
+			statusCode: http.StatusBadGateway,
+			body:       "bad gateway",
+			want: &APIError{
+				StatusCode: http.StatusBadGateway,
+				Status:     "502 Bad Gateway",
+				Method:     http.MethodGet,
+			},
+			wantErr: ErrServer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.requestID != "" {
+					w.Header().Set(requestIDHeader, tt.requestID)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			cl := NewClient(srv.URL)
+			_, err := cl.FetchAccount(context.Background(), "some-id")
+			assert.True(errors.Is(err, tt.wantErr))
+
+			var apiErr *APIError
+			assert.True(errors.As(err, &apiErr))
+
+			tt.want.URL = srv.URL + accountsPath + "/some-id"
+			tt.want.err = tt.wantErr
+			assert.Equal(apiErr, tt.want)
+		})
+	}
+}
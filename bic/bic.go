@@ -0,0 +1,133 @@
+/*
+Package bic parses and validates Business Identifier Codes (BIC, ISO 9362), also known as SWIFT codes.
+
+A BIC consists of a 4 letter institution code, the 2 letter ISO 3166-1 country code, a 2 character
+location code and an optional 3 character branch code:
+
+	code, err := bic.Parse("NWBKGB22")
+	// code.Institution: NWBK, code.Country: GB, code.Location: 22, code.Branch: ""
+
+BICs can optionally be looked up in a Directory, e.g. loaded from a CSV file with LoadCSV, to get the
+name of the institution.
+*/
+package bic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tehsphinx/form3/iso"
+)
+
+const (
+	institutionLength = 4
+	countryLength     = 2
+	locationLength    = 2
+	branchLength      = 3
+	shortLength       = institutionLength + countryLength + locationLength
+	longLength        = shortLength + branchLength
+	// primaryBranch is the branch code of the primary office.
+	primaryBranch = "XXX"
+	// testLocationMarker as second character of the location code marks a test BIC.
+	testLocationMarker = '0'
+	// kosovo is the user-assigned country code of Kosovo.
+	kosovo = "XK"
+)
+
+// validation errors
+var (
+	ErrInvalidLength      = errors.New("bic must have 8 or 11 characters")
+	ErrInvalidInstitution = errors.New("bic institution code must be 4 letters")
+	ErrInvalidCountry     = errors.New("bic country code is not a valid ISO 3166-1 country code")
+	ErrInvalidLocation    = errors.New("bic location code must be 2 letters or digits")
+	ErrInvalidBranch      = errors.New("bic branch code must be 3 letters or digits")
+)
+
+// BIC is a parsed Business Identifier Code.
+type BIC struct {
+	// Institution is the 4 letter code of the bank.
+	Institution string
+	// Country is the ISO 3166-1 alpha-2 country code.
+	Country string
+	// Location is the 2 character location code.
+	Location string
+	// Branch is the 3 character branch code. Empty if the BIC has 8 characters.
+	Branch string
+}
+
+// Parse validates the BIC and splits it into its parts. Surrounding spaces and lower case letters are accepted.
+func Parse(code string) (*BIC, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != shortLength && len(code) != longLength {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidLength, code)
+	}
+
+	b := &BIC{
+		Institution: code[:institutionLength],
+		Country:     code[institutionLength : institutionLength+countryLength],
+		Location:    code[institutionLength+countryLength : shortLength],
+		Branch:      code[shortLength:],
+	}
+	switch {
+	case !isLetters(b.Institution):
+		return nil, fmt.Errorf("%w: %q", ErrInvalidInstitution, b.Institution)
+	case !isCountry(b.Country):
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCountry, b.Country)
+	case !isAlphanumeric(b.Location):
+		return nil, fmt.Errorf("%w: %q", ErrInvalidLocation, b.Location)
+	case !isAlphanumeric(b.Branch):
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBranch, b.Branch)
+	}
+	return b, nil
+}
+
+// Validate checks if the BIC is valid.
+func Validate(code string) error {
+	_, err := Parse(code)
+	return err
+}
+
+// String returns the BIC with 8 or 11 characters as it was parsed.
+func (s *BIC) String() string {
+	return s.Institution + s.Country + s.Location + s.Branch
+}
+
+// Primary returns the 8 character BIC of the primary office of the institution.
+func (s *BIC) Primary() string {
+	return s.Institution + s.Country + s.Location
+}
+
+// IsPrimaryOffice reports if the BIC identifies the primary office (no branch code or XXX).
+func (s *BIC) IsPrimaryOffice() bool {
+	return s.Branch == "" || s.Branch == primaryBranch
+}
+
+// IsTestBIC reports if the BIC is a test and training BIC (location code ending with 0).
+func (s *BIC) IsTestBIC() bool {
+	return s.Location[locationLength-1] == testLocationMarker
+}
+
+// isCountry reports if the code is an ISO 3166-1 alpha-2 country code. Kosovo (XK) is accepted
+// as it is in use by SWIFT although it is not officially assigned.
+func isCountry(code string) bool {
+	return code == kosovo || iso.IsCountry(code)
+}
+
+func isLetters(value string) bool {
+	for _, r := range value {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(value string) bool {
+	for _, r := range value {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package bic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    *BIC
+		wantErr error
+	}{
+		{
+			name: "8 characters",
+			code: "NWBKGB22",
+			want: &BIC{Institution: "NWBK", Country: "GB", Location: "22"},
+		},
+		{
+			name: "11 characters lower case",
+			code: " deutdeff500 ",
+			want: &BIC{Institution: "DEUT", Country: "DE", Location: "FF", Branch: "500"},
+		},
+		{name: "too short", code: "NWBKGB2", wantErr: ErrInvalidLength},
+		{name: "9 characters", code: "NWBKGB22X", wantErr: ErrInvalidLength},
+		{name: "digit in institution", code: "NWB1GB22", wantErr: ErrInvalidInstitution},
+		{name: "unknown country", code: "NWBKZZ22", wantErr: ErrInvalidCountry},
+		{name: "invalid location", code: "NWBKGB2-", wantErr: ErrInvalidLocation},
+		{name: "invalid branch", code: "NWBKGB22X-X", wantErr: ErrInvalidBranch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			got, err := Parse(tt.code)
+			assert.True(errors.Is(err, tt.wantErr))
+			assert.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestBIC(t *testing.T) {
+	assert := is.New(t)
+
+	code, err := Parse("DEUTDEFF500")
+	assert.NoErr(err)
+	assert.Equal(code.String(), "DEUTDEFF500")
+	assert.Equal(code.Primary(), "DEUTDEFF")
+	assert.True(!code.IsPrimaryOffice())
+	assert.True(!code.IsTestBIC())
+
+	code, err = Parse("NWBKGB20XXX")
+	assert.NoErr(err)
+	assert.True(code.IsPrimaryOffice())
+	assert.True(code.IsTestBIC())
+}
+
+func TestMapDirectory_Lookup(t *testing.T) {
+	dir, err := LoadCSV("testdata/directory.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		code   string
+		want   string
+		wantOk bool
+	}{
+		{name: "exact match", code: "NWBKGB22", want: "National Westminster Bank", wantOk: true},
+		{name: "branch of listed primary office", code: "NWBKGB22123", want: "National Westminster Bank", wantOk: true},
+		{name: "primary office listed with XXX", code: "DEUTDEFF", want: "Deutsche Bank", wantOk: true},
+		{name: "unknown", code: "COBADEFF", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			code, err := Parse(tt.code)
+			assert.NoErr(err)
+			inst, ok := dir.Lookup(code)
+			assert.Equal(ok, tt.wantOk)
+			assert.Equal(inst.Name, tt.want)
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		wantErr error
+	}{
+		{name: "columns in any order", csv: "Name,BIC\nABN AMRO,ABNANL2A\n"},
+		{name: "missing name column", csv: "bic,city\nABNANL2A,Amsterdam\n", wantErr: ErrInvalidDirectory},
+		{name: "invalid bic", csv: "bic,name\nABNANL2,ABN AMRO\n", wantErr: ErrInvalidDirectory},
+		{name: "missing columns", csv: "name,city,bic\nABN AMRO\n", wantErr: ErrInvalidDirectory},
+		{name: "empty", csv: "", wantErr: ErrInvalidDirectory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			_, err := ReadCSV(strings.NewReader(tt.csv))
+			assert.True(errors.Is(err, tt.wantErr))
+		})
+	}
+}
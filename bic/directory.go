@@ -0,0 +1,102 @@
+package bic
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// csv columns of a directory file
+const (
+	columnBIC  = "bic"
+	columnName = "name"
+)
+
+// ErrInvalidDirectory is returned if a directory file cannot be parsed.
+var ErrInvalidDirectory = errors.New("invalid bic directory")
+
+// Institution is an entry of a BIC directory.
+type Institution struct {
+	BIC  string
+	Name string
+}
+
+// Directory looks up the institution of a BIC.
+type Directory interface {
+	// Lookup returns the institution of the BIC. Reports false if the BIC is unknown.
+	Lookup(code *BIC) (Institution, bool)
+}
+
+// MapDirectory is a Directory holding the institutions in memory. Create one with LoadCSV or ReadCSV.
+type MapDirectory map[string]Institution
+
+// Lookup implements the Directory interface. A BIC of a branch not listed in the directory
+// is looked up by the BIC of its primary office.
+func (s MapDirectory) Lookup(code *BIC) (Institution, bool) {
+	if inst, ok := s[code.String()]; ok {
+		return inst, true
+	}
+	if inst, ok := s[code.Primary()]; ok {
+		return inst, true
+	}
+	inst, ok := s[code.Primary()+primaryBranch]
+	return inst, ok
+}
+
+// LoadCSV loads a directory from a CSV file. See ReadCSV for the format.
+func LoadCSV(path string) (MapDirectory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadCSV(f)
+}
+
+// ReadCSV reads a directory in CSV format. The first row must be a header naming the columns.
+// The columns "bic" and "name" are required, further columns are ignored.
+func ReadCSV(r io.Reader) (MapDirectory, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header: %v", ErrInvalidDirectory, err)
+	}
+	bicCol, nameCol := -1, -1
+	for i, col := range header {
+		switch strings.ToLower(strings.TrimSpace(col)) {
+		case columnBIC:
+			bicCol = i
+		case columnName:
+			nameCol = i
+		}
+	}
+	if bicCol == -1 || nameCol == -1 {
+		return nil, fmt.Errorf("%w: header requires the columns %q and %q", ErrInvalidDirectory, columnBIC, columnName)
+	}
+
+	dir := MapDirectory{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return dir, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDirectory, err)
+		}
+		if len(record) <= bicCol || len(record) <= nameCol {
+			return nil, fmt.Errorf("%w: line %d: missing columns", ErrInvalidDirectory, line)
+		}
+
+		code, err := Parse(record[bicCol])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidDirectory, line, err)
+		}
+		dir[code.String()] = Institution{BIC: code.String(), Name: strings.TrimSpace(record[nameCol])}
+	}
+}
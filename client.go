@@ -3,6 +3,8 @@ package form3
 import (
 	"net/http"
 	"time"
+
+	"github.com/tehsphinx/form3/httpsig"
 )
 
 const defaultRequestTimeout = 30 * time.Second
@@ -11,18 +13,21 @@ const defaultRequestTimeout = 30 * time.Second
 // Use one or more ClientOption to further configure the client.
 func NewClient(endpoint string, opts ...ClientOption) *Client {
 	cl := &Client{
-		baseURL:           endpoint,
-		maxRequestTimeout: defaultRequestTimeout,
-		validateAccount:   getValidateAccount(),
+		baseURL:            endpoint,
+		maxRequestTimeout:  defaultRequestTimeout,
+		redaction:          DefaultRedactionRules(),
+		validatePayment:    getValidatePayment(),
+		validatePayeeCheck: getValidatePayeeCheck(),
 	}
 
 	for _, opt := range opts {
 		opt(cl)
 	}
 
-	cl.client = &http.Client{
-		Timeout: cl.maxRequestTimeout,
-	}
+	cl.validateAccount = getValidateAccount(cl.accountChecks...)
+	cl.validateReasons = newReasonValidator(cl.strictEnums)
+	cl.validateMandate = getValidateMandate(cl.strictEnums)
+	cl.client = cl.buildHTTPClient()
 	return cl
 }
 
@@ -33,8 +38,70 @@ type Client struct {
 	baseURL string
 	// max time limit for all requests
 	maxRequestTimeout time.Duration
-	// enables debug output
-	enableDbg bool
+	// receives log events if set
+	logger Logger
+	// minimum level of logged events
+	logLevel LogLevel
+	// enables logging request and response bodies
+	logBodies bool
+	// redacts sensitive values before they are logged
+	redaction RedactionRules
+	// retry policy for transient failures. No retries if nil.
+	retryPolicy *RetryPolicy
+	// signs the requests if set
+	signer *httpsig.Signer
+	// provides bearer tokens to authenticate requests if set
+	tokenSource TokenSource
+	// http client provided with WithHTTPClient
+	httpClient *http.Client
+	// middlewares wrapping the transport of the http client. The first one is the outermost.
+	middlewares []func(http.RoundTripper) http.RoundTripper
+	// rejects responses with unknown enum values if set
+	strictEnums bool
+	// additional checks of accounts (e.g. modulus check) run by validateAccount
+	accountChecks []accountCheck
 	// validate function for account
 	validateAccount func(attr *Account) error
+	// validate function for payment
+	validatePayment func(attr *Payment) error
+	// validate function for mandates
+	validateMandate func(attr *Mandate) error
+	// validates returns, reversals and recalls
+	validateReasons reasonValidator
+	// validate function for Confirmation of Payee checks
+	validatePayeeCheck func(attr *PayeeCheck) error
+}
+
+// buildHTTPClient creates the http client used for all requests. The provided http client is copied,
+// so it can be shared with other code without being altered.
+func (s *Client) buildHTTPClient() *http.Client {
+	client := &http.Client{}
+	if s.httpClient != nil {
+		c := *s.httpClient
+		client = &c
+	}
+	client.Timeout = s.maxRequestTimeout
+
+	if len(s.middlewares) == 0 {
+		return client
+	}
+
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		transport = s.middlewares[i](transport)
+	}
+	client.Transport = transport
+	return client
+}
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as http.RoundTripper.
+// Useful to write transport middlewares for WithTransportMiddleware.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements the http.RoundTripper interface.
+func (s RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return s(req)
 }
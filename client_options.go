@@ -1,6 +1,14 @@
 package form3
 
-import "time"
+import (
+	"crypto"
+	"net/http"
+	"time"
+
+	"github.com/tehsphinx/form3/bic"
+	"github.com/tehsphinx/form3/httpsig"
+	"github.com/tehsphinx/form3/modulus"
+)
 
 // ClientOption defines an optional parameter for creating a form3.NewClient client.
 type ClientOption func(cl *Client)
@@ -14,9 +22,112 @@ func WithRequestTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
-// WithDebug enables colorful debugging of the communication.
+// WithDebug enables colorful debugging of the communication including request and response bodies.
+// Sensitive values are redacted (see WithRedaction).
+// It is a shortcut for a colored Logger on debug level with body logging.
 func WithDebug() ClientOption {
 	return func(cl *Client) {
-		cl.enableDbg = true
+		cl.logger = LoggerFunc(dbgLogger)
+		cl.logLevel = LevelDebug
+		cl.logBodies = true
+	}
+}
+
+// WithLogger sets a logger receiving structured events (method, url, status, duration, ...) about all
+// requests. Only events with the given level or higher are logged. Bodies are only logged if enabled
+// with WithBodyLogging, as they might contain sensitive information.
+func WithLogger(logger Logger, level LogLevel) ClientOption {
+	return func(cl *Client) {
+		cl.logger = logger
+		cl.logLevel = level
+	}
+}
+
+// WithBodyLogging enables logging the request and response bodies with the logger set by WithLogger.
+func WithBodyLogging() ClientOption {
+	return func(cl *Client) {
+		cl.logBodies = true
+	}
+}
+
+// WithRetryPolicy enables retrying requests that failed with a transient error like a rate limit or
+// an unavailable server. Only requests that are safe to be repeated are retried. All attempts together
+// are bound by the context of the request and the request timeout of the client.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(cl *Client) {
+		cl.retryPolicy = &policy
+	}
+}
+
+// WithRequestSigning enables signing all requests with the given private key according to draft-cavage
+// HTTP Signatures. The key id identifies the public key registered with the API. RSA, ECDSA and Ed25519
+// keys are supported.
+func WithRequestSigning(keyID string, privateKey crypto.Signer) ClientOption {
+	return func(cl *Client) {
+		cl.signer = httpsig.NewSigner(keyID, privateKey)
+	}
+}
+
+// WithTokenSource enables authenticating all requests with a bearer token obtained from the token source,
+// e.g. NewClientCredentials. If the server rejects a token, it is invalidated and the request is sent once
+// more with a fresh token. Both, tokens and request signing use the Authorization header. Use only one of them.
+func WithTokenSource(source TokenSource) ClientOption {
+	return func(cl *Client) {
+		cl.tokenSource = source
+	}
+}
+
+// WithHTTPClient sets the http client used to send requests. Use it to configure proxies, TLS or
+// connection pooling. The client is copied and its timeout is replaced with the request timeout
+// of the client (see WithRequestTimeout).
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(cl *Client) {
+		cl.httpClient = client
+	}
+}
+
+// WithTransportMiddleware wraps the transport of the http client with the given middlewares, e.g. for
+// logging, metrics or fault injection. Middlewares are applied in the given order: the first one is
+// the outermost and sees the request first. Can be used multiple times to add more middlewares.
+func WithTransportMiddleware(middlewares ...func(http.RoundTripper) http.RoundTripper) ClientOption {
+	return func(cl *Client) {
+		cl.middlewares = append(cl.middlewares, middlewares...)
+	}
+}
+
+// WithRedaction sets the rules to redact sensitive values in logged bodies and urls and in the urls
+// contained in returned errors. It replaces the DefaultRedactionRules masking the personal information
+// of account holders and payment parties. Pass empty rules to disable redaction.
+func WithRedaction(rules RedactionRules) ClientOption {
+	return func(cl *Client) {
+		cl.redaction = rules
+	}
+}
+
+// WithStrictEnums makes the client reject responses holding values of closed value sets (e.g. AccountStatus)
+// unknown to the client with ErrUnknownEnumValue. By default such values are tolerated, so the client keeps
+// working if the API introduces new values. Reason codes of returns, reversals and recalls and payment schemes
+// of mandates unknown to the client are rejected before sending as well.
+func WithStrictEnums() ClientOption {
+	return func(cl *Client) {
+		cl.strictEnums = true
+	}
+}
+
+// WithModulusCheck enables checking the sort code (bankID) and account number of GB accounts with the
+// Vocalink modulus checking before creating them. Accounts failing the check are rejected with ErrModulusCheck.
+// Load the checker from the published weight table with modulus.LoadChecker.
+func WithModulusCheck(checker *modulus.Checker) ClientOption {
+	return func(cl *Client) {
+		cl.accountChecks = append(cl.accountChecks, modulusCheck(checker))
+	}
+}
+
+// WithBICDirectory enables checking that the BIC of an account is listed in the given directory before creating
+// the account. Accounts with an unknown BIC are rejected with ErrUnknownBIC. Load a directory from a CSV file
+// with bic.LoadCSV.
+func WithBICDirectory(dir bic.Directory) ClientOption {
+	return func(cl *Client) {
+		cl.accountChecks = append(cl.accountChecks, bicDirectoryCheck(dir))
 	}
 }
@@ -0,0 +1,68 @@
+package form3_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/tehsphinx/form3"
+	"github.com/tehsphinx/form3/form3test"
+)
+
+func TestClient_WithRequestSigning(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := form3test.NewServer(form3test.WithSignatureVerification("key-1", key.Public()))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		options []form3.ClientOption
+		wantErr error
+	}{
+		{
+			name:    "signed request",
+			options: []form3.ClientOption{form3.WithRequestSigning("key-1", key)},
+		},
+		{
+			name:    "unsigned request",
+			wantErr: form3.ErrUnauthorized,
+		},
+		{
+			name:    "signed with wrong key",
+			options: []form3.ClientOption{form3.WithRequestSigning("key-1", otherKey)},
+			wantErr: form3.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			cl := form3.NewClient(srv.URL, tt.options...)
+			_, err := cl.CreateAccount(ctx, orgID, &form3.Account{
+				Country:    "GB",
+				BankID:     "400300",
+				BankIDCode: "GBDSC",
+				BIC:        "NWBKGB22",
+			})
+			assert.True(errors.Is(err, tt.wantErr))
+
+			_, err = cl.ListAccounts(ctx, form3.WithPageSize(1))
+			assert.True(errors.Is(err, tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestClient_WithTransportMiddleware(t *testing.T) {
+	assert := is.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(r.Header.Get("X-Trace"), "outer,inner")
+		_, _ = w.Write([]byte(accountResponse))
+	}))
+	defer srv.Close()
+
+	var calls []string
+	middleware := func(name string) func(http.RoundTripper) http.RoundTripper {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				trace := req.Header.Get("X-Trace")
+				if trace != "" {
+					trace += ","
+				}
+				req.Header.Set("X-Trace", trace+name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	cl := NewClient(srv.URL, WithTransportMiddleware(middleware("outer")), WithTransportMiddleware(middleware("inner")))
+	_, err := cl.FetchAccount(context.Background(), "some-id")
+	assert.NoErr(err)
+	assert.Equal(calls, []string{"outer", "inner"})
+}
+
+func TestClient_WithHTTPClient(t *testing.T) {
+	assert := is.New(t)
+
+	errInjected := errors.New("injected fault")
+	httpClient := &http.Client{
+		Timeout: time.Hour,
+		Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errInjected
+		}),
+	}
+
+	cl := NewClient("http://localhost", WithHTTPClient(httpClient), WithRequestTimeout(time.Second))
+	assert.Equal(cl.client.Timeout, time.Second)
+	// the provided client is not altered
+	assert.Equal(httpClient.Timeout, time.Hour)
+
+	_, err := cl.FetchAccount(context.Background(), "some-id")
+	assert.True(errors.Is(err, errInjected))
+}
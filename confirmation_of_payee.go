@@ -0,0 +1,89 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const confirmationOfPayeePath = "/v1/services/confirmation-of-payee"
+
+// client side Confirmation of Payee validation errors
+var (
+	ErrInvalidPayeeName          = errors.New("name is required")
+	ErrInvalidSortCode           = errors.New("bankID should be a 6 digit sort code")
+	ErrInvalidPayeeAccountNumber = errors.New("accountNumber should have 8 digits")
+	ErrInvalidAccountType        = errors.New("accountType should be one of [Personal Business]")
+)
+
+// PayeeCheck is a Confirmation of Payee check: it verifies the name of the payee against the account
+// identified by sort code and account number before a payment is sent. The request fields are set by
+// the caller, the result fields are filled by the response of CheckPayee.
+type PayeeCheck struct {
+	baseAttr
+
+	// Name of the payee as entered by the payer.
+	Name string `json:"name"`
+	// AccountNumber and BankID (the sort code) identify the account of the payee.
+	AccountNumber string `json:"account_number"`
+	BankID        string `json:"bank_id"`
+	// AccountType is the type of account the payer expects, personal or business.
+	AccountType AccountClassification `json:"account_type"`
+	// SecondaryIdentification, e.g. the roll number of a building society account.
+	SecondaryIdentification string `json:"secondary_identification,omitempty"`
+
+	// MatchResult tells if the name matches the account. Check ReasonCode for the details of a
+	// close match or no match.
+	MatchResult MatchResult     `json:"match_result,omitempty"`
+	ReasonCode  MatchReasonCode `json:"reason_code,omitempty"`
+	// MatchedName is the name of the account holder. It is only returned for close matches and
+	// names matching an account of the other account type.
+	MatchedName string `json:"matched_name,omitempty"`
+}
+
+// CheckPayee sends a Confirmation of Payee check for the account and returns the result. Run it before
+// initiating a payment to a new payee. Like CreatePayment a check with an id given by WithID or
+// WithDeterministicID can be safely repeated.
+func (s *Client) CheckPayee(ctx context.Context, orgID string, data *PayeeCheck,
+	options ...CreateOption) (*PayeeCheck, error) {
+	if err := s.validatePayeeCheck(data); err != nil {
+		return nil, fmt.Errorf("invalid PayeeCheck information provided: %w", err)
+	}
+
+	resp := &PayeeCheck{}
+	if err := s.create(ctx, confirmationOfPayeePath, typePayeeChecks, orgID, data, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SetAccountMatchingOptOut opts the account with given account id out of (or back into) Confirmation of
+// Payee: checks against an opted out account report no match with MatchReasonOptedOut. Like UpdateAccount
+// the version must match the current version of the account.
+func (s *Client) SetAccountMatchingOptOut(ctx context.Context, uid string, version int, optOut bool) (*Account, error) {
+	return s.UpdateAccount(ctx, uid, version, &AccountPatch{AccountMatchingOptOut: Bool(optOut)})
+}
+
+func getValidatePayeeCheck() func(attr *PayeeCheck) error {
+	sortCodeRE := regexp.MustCompile(`^\d{6}$`)
+	accountNumberRE := regexp.MustCompile(`^\d{8}$`)
+
+	return func(attr *PayeeCheck) error {
+		var errs ValidationErrors
+		if attr.Name == "" {
+			errs.add(attrPath("Name", "name"), attr.Name, ErrInvalidPayeeName)
+		}
+		if !sortCodeRE.MatchString(attr.BankID) {
+			errs.add(attrPath("BankID", "bank_id"), attr.BankID, ErrInvalidSortCode)
+		}
+		if !accountNumberRE.MatchString(attr.AccountNumber) {
+			errs.add(attrPath("AccountNumber", "account_number"), attr.AccountNumber, ErrInvalidPayeeAccountNumber)
+		}
+		if !attr.AccountType.IsValid() {
+			errs.add(attrPath("AccountType", "account_type"), attr.AccountType.String(), ErrInvalidAccountType)
+		}
+		return errs.err()
+	}
+}
@@ -0,0 +1,100 @@
+package form3_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/tehsphinx/form3"
+	"github.com/tehsphinx/form3/form3test"
+)
+
+// TestClient_CheckPayee runs Confirmation of Payee checks against the accounts of the fake server.
+func TestClient_CheckPayee(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	srv := form3test.NewServer()
+	defer srv.Close()
+	cl := form3.NewClient(srv.URL, form3.WithStrictEnums())
+
+	personal, err := cl.CreateAccount(ctx, orgID, &form3.Account{
+		Country: "GB", BankID: "400300", BankIDCode: "GBDSC", BIC: "NWBKGB22", AccountNumber: "41426819",
+		Name: []string{"Samantha", "Holder"}, AlternativeNames: []string{"Sam Holder"},
+		AccountClassification: form3.AccountClassificationPersonal,
+	})
+	is.New(t).NoErr(err)
+	_, err = cl.CreateAccount(ctx, orgID, &form3.Account{
+		Country: "GB", BankID: "400300", BankIDCode: "GBDSC", BIC: "NWBKGB22", AccountNumber: "31926819",
+		Name: []string{"Holder Trading Ltd"}, AccountClassification: form3.AccountClassificationBusiness,
+	})
+	is.New(t).NoErr(err)
+
+	tests := []struct {
+		name            string
+		payee           string
+		accountNumber   string
+		accountType     form3.AccountClassification
+		wantResult      form3.MatchResult
+		wantReason      form3.MatchReasonCode
+		wantMatchedName string
+	}{
+		{name: "match", payee: "samantha holder", accountNumber: "41426819",
+			accountType: form3.AccountClassificationPersonal, wantResult: form3.MatchResultMatch},
+		{name: "alternative name", payee: "Sam Holder", accountNumber: "41426819",
+			accountType: form3.AccountClassificationPersonal, wantResult: form3.MatchResultMatch},
+		{name: "close match", payee: "S. Holder", accountNumber: "41426819",
+			accountType: form3.AccountClassificationPersonal, wantResult: form3.MatchResultCloseMatch,
+			wantReason: form3.MatchReasonMayBeMatch, wantMatchedName: "Samantha Holder"},
+		{name: "no match", payee: "John Smith", accountNumber: "41426819",
+			accountType: form3.AccountClassificationPersonal, wantResult: form3.MatchResultNoMatch,
+			wantReason: form3.MatchReasonNameNoMatch},
+		{name: "business account", payee: "Holder Trading Ltd", accountNumber: "31926819",
+			accountType: form3.AccountClassificationPersonal, wantResult: form3.MatchResultCloseMatch,
+			wantReason: form3.MatchReasonBusinessNameMatch, wantMatchedName: "Holder Trading Ltd"},
+		{name: "unknown account", payee: "Samantha Holder", accountNumber: "11111111",
+			accountType: form3.AccountClassificationPersonal, wantResult: form3.MatchResultNoMatch,
+			wantReason: form3.MatchReasonAccountNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			check, err := cl.CheckPayee(ctx, orgID, &form3.PayeeCheck{
+				Name: tt.payee, BankID: "400300", AccountNumber: tt.accountNumber, AccountType: tt.accountType,
+			})
+			assert.NoErr(err)
+			assert.Equal(check.MatchResult, tt.wantResult)
+			assert.Equal(check.ReasonCode, tt.wantReason)
+			assert.Equal(check.MatchedName, tt.wantMatchedName)
+		})
+	}
+
+	t.Run("opted out", func(t *testing.T) {
+		assert := is.New(t)
+
+		account, err := cl.SetAccountMatchingOptOut(ctx, personal.ID(), personal.Version(), true)
+		assert.NoErr(err)
+		assert.True(account.AccountMatchingOptOut)
+
+		check, err := cl.CheckPayee(ctx, orgID, &form3.PayeeCheck{Name: "Samantha Holder", BankID: "400300",
+			AccountNumber: "41426819", AccountType: form3.AccountClassificationPersonal})
+		assert.NoErr(err)
+		assert.Equal(check.MatchResult, form3.MatchResultNoMatch)
+		assert.Equal(check.ReasonCode, form3.MatchReasonOptedOut)
+
+		// opt back in
+		account, err = cl.SetAccountMatchingOptOut(ctx, account.ID(), account.Version(), false)
+		assert.NoErr(err)
+		assert.True(!account.AccountMatchingOptOut)
+		_, err = cl.SetAccountMatchingOptOut(ctx, account.ID(), personal.Version(), true)
+		assert.True(errors.Is(err, form3.ErrConflict))
+	})
+
+	t.Run("invalid check", func(t *testing.T) {
+		_, err := cl.CheckPayee(ctx, orgID, &form3.PayeeCheck{Name: "Samantha Holder", BankID: "400300"})
+		is.New(t).True(errors.Is(err, form3.ErrInvalidPayeeAccountNumber))
+	})
+}
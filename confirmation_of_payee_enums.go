@@ -0,0 +1,88 @@
+package form3
+
+import "fmt"
+
+// MatchResult is the result of a Confirmation of Payee check.
+type MatchResult string
+
+// match results
+const (
+	MatchResultMatch      MatchResult = "match"
+	MatchResultCloseMatch MatchResult = "close_match"
+	MatchResultNoMatch    MatchResult = "no_match"
+)
+
+func matchResults() []MatchResult {
+	return []MatchResult{MatchResultMatch, MatchResultCloseMatch, MatchResultNoMatch}
+}
+
+// IsValid checks if the result is one of the known results.
+func (s MatchResult) IsValid() bool {
+	for _, value := range matchResults() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s MatchResult) String() string {
+	return string(s)
+}
+
+// MatchReasonCode explains a close match or no match result of a Confirmation of Payee (CoP) check.
+type MatchReasonCode string
+
+// match reason codes
+const (
+	MatchReasonNameNoMatch             MatchReasonCode = "ANNM" // name does not match
+	MatchReasonMayBeMatch              MatchReasonCode = "MBAM" // name is close
+	MatchReasonBusinessNameMatch       MatchReasonCode = "BANM" // name matches a business account
+	MatchReasonPersonalNameMatch       MatchReasonCode = "PANM" // name matches a personal account
+	MatchReasonBusinessCloseMatch      MatchReasonCode = "BAMM" // name is close to a business account
+	MatchReasonPersonalCloseMatch      MatchReasonCode = "PAMM" // name is close to a personal account
+	MatchReasonAccountNotFound         MatchReasonCode = "AC01" // account does not exist
+	MatchReasonInvalidSecondaryID      MatchReasonCode = "IVCR" // secondary identification missing or wrong
+	MatchReasonAccountTypeNotSupported MatchReasonCode = "ACNS" // account does not support CoP
+	MatchReasonOptedOut                MatchReasonCode = "OPTO" // account holder opted out of CoP
+	MatchReasonAccountSwitched         MatchReasonCode = "CASS" // account switched to another bank
+	MatchReasonSortCodeNotSupported    MatchReasonCode = "SCNS" // bank does not take part in CoP
+)
+
+func matchReasonCodes() []MatchReasonCode {
+	return []MatchReasonCode{
+		MatchReasonNameNoMatch, MatchReasonMayBeMatch, MatchReasonBusinessNameMatch,
+		MatchReasonPersonalNameMatch, MatchReasonBusinessCloseMatch, MatchReasonPersonalCloseMatch,
+		MatchReasonAccountNotFound, MatchReasonInvalidSecondaryID, MatchReasonAccountTypeNotSupported,
+		MatchReasonOptedOut, MatchReasonAccountSwitched, MatchReasonSortCodeNotSupported,
+	}
+}
+
+// IsValid checks if the code is one of the known reason codes.
+func (s MatchReasonCode) IsValid() bool {
+	for _, value := range matchReasonCodes() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s MatchReasonCode) String() string {
+	return string(s)
+}
+
+// checkEnums implements the enumChecker interface. See WithStrictEnums.
+func (s *PayeeCheck) checkEnums() error {
+	switch {
+	case s.AccountType != "" && !s.AccountType.IsValid():
+		return fmt.Errorf("%w: account_type %q", ErrUnknownEnumValue, s.AccountType)
+	case s.MatchResult != "" && !s.MatchResult.IsValid():
+		return fmt.Errorf("%w: match_result %q", ErrUnknownEnumValue, s.MatchResult)
+	case s.ReasonCode != "" && !s.ReasonCode.IsValid():
+		return fmt.Errorf("%w: reason_code %q", ErrUnknownEnumValue, s.ReasonCode)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package form3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func Test_getValidatePayeeCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   *PayeeCheck
+		wantErr error
+	}{
+		{name: "valid", check: &PayeeCheck{Name: "Samantha Holder", BankID: "400300", AccountNumber: "41426819",
+			AccountType: AccountClassificationPersonal}},
+		{name: "missing name", check: &PayeeCheck{BankID: "400300", AccountNumber: "41426819",
+			AccountType: AccountClassificationPersonal}, wantErr: ErrInvalidPayeeName},
+		{name: "invalid sort code", check: &PayeeCheck{Name: "S Holder", BankID: "40-03-00", AccountNumber: "41426819",
+			AccountType: AccountClassificationPersonal}, wantErr: ErrInvalidSortCode},
+		{name: "short account number", check: &PayeeCheck{Name: "S Holder", BankID: "400300", AccountNumber: "4142681",
+			AccountType: AccountClassificationPersonal}, wantErr: ErrInvalidPayeeAccountNumber},
+		{name: "missing account type", check: &PayeeCheck{Name: "S Holder", BankID: "400300", AccountNumber: "41426819"},
+			wantErr: ErrInvalidAccountType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			err := getValidatePayeeCheck()(tt.check)
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+				return
+			}
+			assert.True(errors.Is(err, tt.wantErr))
+		})
+	}
+}
+
+func TestPayeeCheckEnums_IsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{ IsValid() bool }
+		want  bool
+	}{
+		{name: "match", value: MatchResultMatch, want: true},
+		{name: "unknown result", value: MatchResult("partial_match"), want: false},
+		{name: "opted out", value: MatchReasonOptedOut, want: true},
+		{name: "unknown reason", value: MatchReasonCode("XXXX"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+			assert.Equal(tt.value.IsValid(), tt.want)
+		})
+	}
+}
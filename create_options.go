@@ -0,0 +1,73 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// CreateOption defines an optional parameter type for create calls.
+type CreateOption func(opts *createOptions)
+
+type createOptions struct {
+	uid string
+}
+
+// WithID sets the id of the record to be created instead of generating a random one.
+// Knowing the id upfront allows to safely repeat a create call, e.g. if the response was lost.
+func WithID(uid string) CreateOption {
+	return func(opts *createOptions) {
+		opts.uid = uid
+	}
+}
+
+// WithDeterministicID derives the id of the record to be created from the namespace and a business key
+// (UUID version 5). Creating a record with the same namespace and key always results in the same id.
+func WithDeterministicID(namespace uuid.UUID, key string) CreateOption {
+	return func(opts *createOptions) {
+		opts.uid = uuid.NewSHA1(namespace, []byte(key)).String()
+	}
+}
+
+func applyCreateOptions(options []CreateOption) *createOptions {
+	opts := &createOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+	if opts.uid == "" {
+		opts.uid = uuid.NewString()
+	}
+	return opts
+}
+
+// createdRecord is a record that can be created with create.
+type createdRecord interface {
+	responseFiller
+	OrganisationID() string
+}
+
+// create posts a new record of given type to the path and fills resp with the created record. If a
+// record with the id already exists in the organisation (e.g. created by an earlier attempt whose
+// response got lost) it is fetched into resp instead. Otherwise the conflict error is returned.
+func (s *Client) create(ctx context.Context, path string, typ attrType, orgID string, data interface{},
+	resp createdRecord, options []CreateOption) error {
+	opts := applyCreateOptions(options)
+
+	uri := s.buildURL(path, "", nil)
+	err := s.request(ctx, uri, typ, withMethod(http.MethodPost), withOrgID(orgID),
+		withUID(opts.uid), withReq(data), withResp(resp), withStatusOk(http.StatusCreated))
+	if !errors.Is(err, ErrConflict) {
+		return err
+	}
+
+	if fetchErr := s.request(ctx, s.buildURL(path, opts.uid, nil), typ, withResp(resp)); fetchErr != nil {
+		return fmt.Errorf("fetching existing record failed: %w", fetchErr)
+	}
+	if resp.OrganisationID() != orgID {
+		return err
+	}
+	return nil
+}
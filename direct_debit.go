@@ -0,0 +1,95 @@
+package form3
+
+import (
+	"context"
+	"net/url"
+)
+
+const directDebitsPath = "/v1/transaction/directdebits"
+
+// DirectDebit is a direct debit collected from the account of the debtor under a mandate.
+type DirectDebit struct {
+	baseAttr
+
+	// Amount is the decimal amount of the direct debit in the currency, e.g. "100.21".
+	Amount           string            `json:"amount"`
+	Currency         string            `json:"currency"`
+	PaymentScheme    DirectDebitScheme `json:"payment_scheme,omitempty"`
+	DebtorParty      *PaymentParty     `json:"debtor_party,omitempty"`
+	BeneficiaryParty *PaymentParty     `json:"beneficiary_party,omitempty"`
+	// MandateReference is the reference of the mandate the direct debit is collected under.
+	MandateReference string `json:"mandate_reference,omitempty"`
+	// SequenceType of a SEPA direct debit, e.g. FRST for the first collection of a mandate.
+	SequenceType SequenceType `json:"sequence_type,omitempty"`
+	// ProcessingDate is the date the direct debit is collected formatted as YYYY-MM-DD.
+	ProcessingDate string `json:"processing_date,omitempty"`
+	Reference      string `json:"reference,omitempty"`
+}
+
+// FetchDirectDebit retrieves the direct debit with given direct debit id.
+func (s *Client) FetchDirectDebit(ctx context.Context, uid string) (*DirectDebit, error) {
+	resp := &DirectDebit{}
+	uri := s.buildURL(directDebitsPath, uid, nil)
+	if err := s.request(ctx, uri, typeDirectDebits, withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListDirectDebits retrieves a list of direct debits. It has pagination (e.g. WithPageSize).
+func (s *Client) ListDirectDebits(ctx context.Context, opts ...ListOption) ([]DirectDebit, error) {
+	params := url.Values{}
+	applyOptions(params, opts)
+
+	var directDebits []DirectDebit
+	uri := s.buildURL(directDebitsPath, "", params)
+	if err := s.request(ctx, uri, typeDirectDebits,
+		withListResp(
+			func() responseFiller {
+				return &DirectDebit{}
+			},
+			func(data responseFiller) {
+				directDebit := data.(*DirectDebit)
+				directDebits = append(directDebits, *directDebit)
+			},
+		),
+	); err != nil {
+		return nil, err
+	}
+
+	return directDebits, nil
+}
+
+// ReturnDirectDebit returns the direct debit with given direct debit id to the beneficiary, e.g. because
+// there is no mandate (ReturnCodeNoMandate).
+func (s *Client) ReturnDirectDebit(ctx context.Context, orgID, directDebitID string, data *Return,
+	options ...CreateOption) (*Return, error) {
+	if err := s.validateReasons.validateReturn(data); err != nil {
+		return nil, err
+	}
+
+	resp := &Return{}
+	path := resourcePath(directDebitsPath, directDebitID, returnsPath)
+	if err := s.create(ctx, path, typeDirectDebitReturns, orgID, data, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ReverseDirectDebit reverses the direct debit with given direct debit id, e.g. because it was collected twice.
+func (s *Client) ReverseDirectDebit(ctx context.Context, orgID, directDebitID string, data *Reversal,
+	options ...CreateOption) (*Reversal, error) {
+	if err := s.validateReasons.validateReversal(data); err != nil {
+		return nil, err
+	}
+
+	resp := &Reversal{}
+	path := resourcePath(directDebitsPath, directDebitID, reversalsPath)
+	if err := s.create(ctx, path, typeDirectDebitReversals, orgID, data, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
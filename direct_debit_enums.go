@@ -0,0 +1,113 @@
+package form3
+
+import "fmt"
+
+// DirectDebitScheme is the scheme direct debits are collected with.
+type DirectDebitScheme string
+
+// direct debit schemes
+const (
+	DirectDebitSchemeBacs DirectDebitScheme = "BACS"
+	DirectDebitSchemeSEPA DirectDebitScheme = "SEPADD"
+)
+
+func directDebitSchemes() []DirectDebitScheme {
+	return []DirectDebitScheme{DirectDebitSchemeBacs, DirectDebitSchemeSEPA}
+}
+
+// IsValid checks if the scheme is one of the known schemes.
+func (s DirectDebitScheme) IsValid() bool {
+	for _, value := range directDebitSchemes() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s DirectDebitScheme) String() string {
+	return string(s)
+}
+
+// MandateStatus is the status of a mandate.
+type MandateStatus string
+
+// mandate statuses
+const (
+	MandateStatusPending   MandateStatus = "pending"
+	MandateStatusActive    MandateStatus = "active"
+	MandateStatusCancelled MandateStatus = "cancelled"
+	MandateStatusFailed    MandateStatus = "failed"
+)
+
+func mandateStatuses() []MandateStatus {
+	return []MandateStatus{MandateStatusPending, MandateStatusActive, MandateStatusCancelled, MandateStatusFailed}
+}
+
+// IsValid checks if the status is one of the known statuses.
+func (s MandateStatus) IsValid() bool {
+	for _, value := range mandateStatuses() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s MandateStatus) String() string {
+	return string(s)
+}
+
+// SequenceType is the position of a SEPA direct debit in the sequence of collections of a mandate.
+type SequenceType string
+
+// sequence types
+const (
+	SequenceTypeFirst     SequenceType = "FRST"
+	SequenceTypeRecurring SequenceType = "RCUR"
+	SequenceTypeFinal     SequenceType = "FNAL"
+	SequenceTypeOneOff    SequenceType = "OOFF"
+)
+
+func sequenceTypes() []SequenceType {
+	return []SequenceType{SequenceTypeFirst, SequenceTypeRecurring, SequenceTypeFinal, SequenceTypeOneOff}
+}
+
+// IsValid checks if the sequence type is one of the known sequence types.
+func (s SequenceType) IsValid() bool {
+	for _, value := range sequenceTypes() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s SequenceType) String() string {
+	return string(s)
+}
+
+// checkEnums implements the enumChecker interface. See WithStrictEnums.
+func (s *Mandate) checkEnums() error {
+	switch {
+	case s.PaymentScheme != "" && !s.PaymentScheme.IsValid():
+		return fmt.Errorf("%w: payment_scheme %q", ErrUnknownEnumValue, s.PaymentScheme)
+	case s.Status != "" && !s.Status.IsValid():
+		return fmt.Errorf("%w: status %q", ErrUnknownEnumValue, s.Status)
+	}
+	return nil
+}
+
+// checkEnums implements the enumChecker interface. See WithStrictEnums.
+func (s *DirectDebit) checkEnums() error {
+	switch {
+	case s.PaymentScheme != "" && !s.PaymentScheme.IsValid():
+		return fmt.Errorf("%w: payment_scheme %q", ErrUnknownEnumValue, s.PaymentScheme)
+	case s.SequenceType != "" && !s.SequenceType.IsValid():
+		return fmt.Errorf("%w: sequence_type %q", ErrUnknownEnumValue, s.SequenceType)
+	}
+	return nil
+}
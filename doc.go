@@ -2,5 +2,9 @@
 Package form3 contains an API client for the form3 API. (Currently only a small part of it. One can dream, right?)
 
 For more information check out the README.md file or consult godoc for this module.
+
+Requests can be logged with WithLogger. Personal information like names, addresses and account numbers
+of accounts and payment parties is redacted from the logged bodies, urls and errors by the
+DefaultRedactionRules. Use WithRedaction to change the rules.
 */
 package form3
@@ -0,0 +1,56 @@
+package form3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// attribute rules of the account API. Only string attributes are checked.
+type attributeRule struct {
+	name     string
+	required bool
+	re       *regexp.Regexp
+}
+
+func accountRules() []attributeRule {
+	return []attributeRule{
+		{name: "country", required: true, re: regexp.MustCompile("^[A-Z]{2}$")},
+		{name: "base_currency", re: regexp.MustCompile("^[A-Z]{3}$")},
+		{name: "bank_id", re: regexp.MustCompile("^[A-Z0-9]{0,16}$")},
+		{name: "bank_id_code", re: regexp.MustCompile("^[A-Z]{0,16}$")},
+		{name: "bic", re: regexp.MustCompile("^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$")},
+		{name: "account_classification", re: regexp.MustCompile("^(Personal|Business)$")},
+	}
+}
+
+// getValidateAccount returns a validator mimicking the validation of the account API.
+func getValidateAccount() validator {
+	rules := accountRules()
+	return func(attr map[string]json.RawMessage) []string {
+		return validateRules(rules, attr)
+	}
+}
+
+func validateRules(rules []attributeRule, attr map[string]json.RawMessage) []string {
+	var msgs []string
+	for _, rule := range rules {
+		raw, ok := attr[rule.name]
+		if !ok {
+			if rule.required {
+				msgs = append(msgs, fmt.Sprintf("%s in body is required", rule.name))
+			}
+			continue
+		}
+
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s in body must be of type string", rule.name))
+			continue
+		}
+		if !rule.re.MatchString(value) {
+			msgs = append(msgs, fmt.Sprintf("%s in body should match '%s'", rule.name, rule.re))
+		}
+	}
+	return msgs
+}
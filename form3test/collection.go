@@ -0,0 +1,456 @@
+package form3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultPageSize = 100
+
+// validator checks the attributes of a record and returns a message for every violation.
+type validator func(attr map[string]json.RawMessage) []string
+
+// collection stores the records of one resource type and serves its endpoints.
+type collection struct {
+	typ      string
+	path     string
+	validate validator
+	uuidRE   *regexp.Regexp
+	// attributes that are not stored
+	ignored map[string]bool
+	// defaults holds attributes set on creation if the client did not provide them
+	defaults map[string]json.RawMessage
+	// process completes the attributes of a created record server side, e.g. with a result
+	process func(attributes map[string]json.RawMessage)
+	// subResources are the nested resources of a record, e.g. the submissions of a payment
+	subResources map[string]subResource
+	// incoming records are only received from the scheme (see Server.AddDirectDebit): clients can't create them
+	incoming bool
+
+	m       sync.Mutex
+	records map[string]*record
+	// order holds the ids of the records in creation order
+	order []string
+	// children holds the nested collections by parent id and sub-resource name
+	children map[string]*collection
+}
+
+// subResource defines a resource nested under the records of a collection.
+type subResource struct {
+	typ      string
+	validate validator
+	defaults map[string]json.RawMessage
+	// nested are the sub-resources of the sub-resource, e.g. the submissions of a return
+	nested map[string]subResource
+}
+
+func newCollection(typ, path string, validate validator) *collection {
+	return &collection{
+		typ:      typ,
+		path:     path,
+		validate: validate,
+		uuidRE:   regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"),
+		records:  map[string]*record{},
+		children: map[string]*collection{},
+	}
+}
+
+// nest registers a sub-resource served at <path>/<uid>/<name>.
+func (s *collection) nest(name string, sub subResource) {
+	if s.subResources == nil {
+		s.subResources = map[string]subResource{}
+	}
+	s.subResources[name] = sub
+}
+
+// child returns the nested collection of the record. It reports false if the record or the
+// sub-resource does not exist. The caller must hold the lock.
+func (s *collection) child(uid, name string) (*collection, bool) {
+	sub, ok := s.subResources[name]
+	if _, exists := s.records[uid]; !ok || !exists {
+		return nil, false
+	}
+
+	key := uid + "/" + name
+	c, ok := s.children[key]
+	if !ok {
+		c = newCollection(sub.typ, s.path+"/"+key, sub.validate)
+		c.defaults = sub.defaults
+		c.subResources = sub.nested
+		s.children[key] = c
+	}
+	return c, true
+}
+
+func (s *collection) count() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return len(s.records)
+}
+
+func (s *collection) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	uid := strings.Trim(strings.TrimPrefix(r.URL.Path, s.path), "/")
+	if i := strings.Index(uid, "/"); i != -1 {
+		s.serveNested(w, r, uid[:i], uid[i+1:])
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	switch {
+	case uid == "" && r.Method == http.MethodGet:
+		s.list(w, r)
+	case uid == "" && r.Method == http.MethodPost && !s.incoming:
+		s.create(w, r)
+	case uid != "" && r.Method == http.MethodGet:
+		s.fetch(w, uid)
+	case uid != "" && r.Method == http.MethodPatch:
+		s.update(w, r, uid)
+	case uid != "" && r.Method == http.MethodDelete:
+		s.delete(w, r, uid)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// serveNested passes the request on to the nested collection of the record.
+func (s *collection) serveNested(w http.ResponseWriter, r *http.Request, uid, rest string) {
+	name := rest
+	if i := strings.Index(rest, "/"); i != -1 {
+		name = rest[:i]
+	}
+
+	c, ok := s.nested(uid, name)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", uid))
+		return
+	}
+	c.ServeHTTP(w, r)
+}
+
+func (s *collection) create(w http.ResponseWriter, r *http.Request) {
+	var req envelope
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Data == nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rec := req.Data
+	msgs := s.validateEnvelope(rec)
+	if rec.Attributes == nil {
+		msgs = append(msgs, "attributes in body is required")
+	} else {
+		msgs = append(msgs, s.validate(rec.Attributes)...)
+	}
+	if len(msgs) != 0 {
+		writeError(w, http.StatusBadRequest, append([]string{"validation failure list:"}, msgs...)...)
+		return
+	}
+
+	if _, ok := s.records[rec.ID]; ok {
+		writeError(w, http.StatusConflict,
+			fmt.Sprintf("%s cannot be created as it violates a duplicate constraint", s.typ))
+		return
+	}
+
+	s.dropIgnored(rec.Attributes)
+	if s.process != nil {
+		s.process(rec.Attributes)
+	}
+	s.insert(rec)
+
+	writeJSON(w, http.StatusCreated, envelope{Data: rec, Links: s.selfLink(rec.ID)})
+}
+
+// insert stores a new record with version 0. The caller must hold the lock.
+func (s *collection) insert(rec *record) {
+	for name, value := range s.defaults {
+		if _, ok := rec.Attributes[name]; !ok {
+			rec.Attributes[name] = value
+		}
+	}
+	now := time.Now().UTC()
+	version := 0
+	rec.Version = &version
+	rec.CreatedOn = now
+	rec.ModifiedOn = now
+	s.records[rec.ID] = rec
+	s.order = append(s.order, rec.ID)
+}
+
+// add stores a new record created server side.
+func (s *collection) add(rec *record) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.insert(rec)
+}
+
+// setAttribute sets an attribute of a record as if it was changed server side and increments its version.
+// It reports false if the record does not exist.
+func (s *collection) setAttribute(uid, name string, value json.RawMessage) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	rec, ok := s.records[uid]
+	if !ok {
+		return false
+	}
+	attributes := make(map[string]json.RawMessage, len(rec.Attributes)+1)
+	for key, v := range rec.Attributes {
+		attributes[key] = v
+	}
+	attributes[name] = value
+
+	version := *rec.Version + 1
+	updated := *rec
+	updated.Version = &version
+	updated.ModifiedOn = time.Now().UTC()
+	updated.Attributes = attributes
+	s.records[uid] = &updated
+	return true
+}
+
+// organisationOf returns the organisation id of the record. It reports false if the record does not exist.
+func (s *collection) organisationOf(uid string) (string, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	rec, ok := s.records[uid]
+	if !ok {
+		return "", false
+	}
+	return rec.OrganisationID, true
+}
+
+// find returns the first record in creation order the match function reports true for.
+func (s *collection) find(match func(rec *record) bool) (*record, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	for _, uid := range s.order {
+		if rec := s.records[uid]; match(rec) {
+			return rec, true
+		}
+	}
+	return nil, false
+}
+
+// nested returns the nested collection of the record. It reports false if the record does not exist.
+func (s *collection) nested(uid, name string) (*collection, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.child(uid, name)
+}
+
+func (s *collection) validateEnvelope(rec *record) []string {
+	var msgs []string
+	if rec.Type != s.typ {
+		msgs = append(msgs, fmt.Sprintf("type in body should be one of [%s]", s.typ))
+	}
+	if !s.uuidRE.MatchString(rec.ID) {
+		msgs = append(msgs, "id in body must be of type uuid")
+	}
+	if !s.uuidRE.MatchString(rec.OrganisationID) {
+		msgs = append(msgs, "organisation_id in body must be of type uuid")
+	}
+	return msgs
+}
+
+func (s *collection) dropIgnored(attributes map[string]json.RawMessage) {
+	for name := range s.ignored {
+		delete(attributes, name)
+	}
+}
+
+func (s *collection) fetch(w http.ResponseWriter, uid string) {
+	rec, ok := s.records[uid]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", uid))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, envelope{Data: rec, Links: s.selfLink(uid)})
+}
+
+func (s *collection) update(w http.ResponseWriter, r *http.Request, uid string) {
+	rec, ok := s.records[uid]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", uid))
+		return
+	}
+
+	var req envelope
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Data == nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Data.Version == nil {
+		writeError(w, http.StatusBadRequest, "validation failure list:", "version in body is required")
+		return
+	}
+	if *req.Data.Version != *rec.Version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	attributes := make(map[string]json.RawMessage, len(rec.Attributes)+len(req.Data.Attributes))
+	for key, value := range rec.Attributes {
+		attributes[key] = value
+	}
+	for key, value := range req.Data.Attributes {
+		attributes[key] = value
+	}
+	if msgs := s.validate(attributes); len(msgs) != 0 {
+		writeError(w, http.StatusBadRequest, append([]string{"validation failure list:"}, msgs...)...)
+		return
+	}
+
+	s.dropIgnored(attributes)
+	version := *rec.Version + 1
+	updated := *rec
+	updated.Version = &version
+	updated.ModifiedOn = time.Now().UTC()
+	updated.Attributes = attributes
+	s.records[uid] = &updated
+
+	writeJSON(w, http.StatusOK, envelope{Data: &updated, Links: s.selfLink(uid)})
+}
+
+func (s *collection) delete(w http.ResponseWriter, r *http.Request, uid string) {
+	version, err := strconv.Atoi(r.URL.Query().Get("version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	rec, ok := s.records[uid]
+	// like the real API a version that does not exist (yet) is reported as not found
+	if !ok || version > *rec.Version {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", uid))
+		return
+	}
+	if version != *rec.Version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	delete(s.records, uid)
+	for name := range s.subResources {
+		delete(s.children, uid+"/"+name)
+	}
+	for i, id := range s.order {
+		if id == uid {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *collection) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	records := s.filter(query)
+
+	size := defaultPageSize
+	if v := query.Get("page[size]"); v != "" {
+		var err error
+		if size, err = strconv.Atoi(v); err != nil || size < 1 {
+			writeError(w, http.StatusBadRequest, "invalid page size")
+			return
+		}
+	}
+
+	lastPage := 0
+	if len(records) > 0 {
+		lastPage = (len(records) - 1) / size
+	}
+	page := 0
+	switch v := query.Get("page[number]"); v {
+	case "", "first":
+	case "last":
+		page = lastPage
+	default:
+		var err error
+		if page, err = strconv.Atoi(v); err != nil || page < 0 {
+			writeError(w, http.StatusBadRequest, "invalid page number")
+			return
+		}
+	}
+
+	data := []*record{}
+	for i := page * size; i < (page+1)*size && i < len(records); i++ {
+		data = append(data, records[i])
+	}
+
+	writeJSON(w, http.StatusOK, listEnvelope{
+		Data:  data,
+		Links: s.pageLinks(query, page, lastPage, size),
+	})
+}
+
+// filter returns all records in creation order matching the filter[attribute] query parameters.
+func (s *collection) filter(query url.Values) []*record {
+	records := make([]*record, 0, len(s.order))
+	for _, uid := range s.order {
+		rec := s.records[uid]
+		if matchesFilter(rec, query) {
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
+func matchesFilter(rec *record, query url.Values) bool {
+	for key := range query {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		attribute := strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]")
+
+		var value string
+		_ = json.Unmarshal(rec.Attributes[attribute], &value)
+		if value != query.Get(key) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *collection) selfLink(uid string) map[string]string {
+	return map[string]string{
+		"self": s.path + "/" + uid,
+	}
+}
+
+func (s *collection) pageLinks(query url.Values, page, lastPage, size int) map[string]string {
+	link := func(number string) string {
+		params := url.Values{}
+		for key := range query {
+			params.Set(key, query.Get(key))
+		}
+		params.Set("page[number]", number)
+		params.Set("page[size]", strconv.Itoa(size))
+		return s.path + "?" + params.Encode()
+	}
+
+	links := map[string]string{
+		"self":  link(strconv.Itoa(page)),
+		"first": link("first"),
+		"last":  link("last"),
+	}
+	if page < lastPage {
+		links["next"] = link(strconv.Itoa(page + 1))
+	}
+	if page > 0 {
+		links["prev"] = link(strconv.Itoa(page - 1))
+	}
+	return links
+}
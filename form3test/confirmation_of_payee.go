@@ -0,0 +1,148 @@
+package form3test
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Confirmation of Payee results
+const (
+	matchResultMatch      = "match"
+	matchResultCloseMatch = "close_match"
+	matchResultNoMatch    = "no_match"
+)
+
+// name match qualities
+const (
+	nameNoMatch = iota
+	nameCloseMatch
+	nameMatch
+)
+
+func payeeCheckRules() []attributeRule {
+	return []attributeRule{
+		{name: "name", required: true, re: regexp.MustCompile("^.+$")},
+		{name: "bank_id", required: true, re: regexp.MustCompile(`^\d{6}$`)},
+		{name: "account_number", required: true, re: regexp.MustCompile(`^\d{8}$`)},
+		{name: "account_type", required: true, re: regexp.MustCompile("^(Personal|Business)$")},
+	}
+}
+
+// newPayeeChecks creates the Confirmation of Payee collection. Checks are answered with the accounts
+// stored in the accounts collection.
+func newPayeeChecks(accounts *collection) *collection {
+	checks := newCollection("confirmation_of_payee", confirmationOfPayeePath, getValidateRules(payeeCheckRules()))
+	checks.process = func(attributes map[string]json.RawMessage) {
+		result, reason, matchedName := checkPayee(accounts, attributes)
+		attributes["match_result"], _ = json.Marshal(result)
+		if reason != "" {
+			attributes["reason_code"], _ = json.Marshal(reason)
+		}
+		if matchedName != "" {
+			attributes["matched_name"], _ = json.Marshal(matchedName)
+		}
+	}
+	return checks
+}
+
+// checkPayee mimics the Confirmation of Payee responder. Names are compared case insensitive and
+// without punctuation. A name with the same surname and first initial is a close match.
+func checkPayee(accounts *collection, check map[string]json.RawMessage) (result, reason, matchedName string) {
+	bankID, accountNumber := stringAttr(check, "bank_id"), stringAttr(check, "account_number")
+	account, ok := accounts.find(func(rec *record) bool {
+		return stringAttr(rec.Attributes, "bank_id") == bankID &&
+			stringAttr(rec.Attributes, "account_number") == accountNumber
+	})
+	switch {
+	case !ok:
+		return matchResultNoMatch, "AC01", ""
+	case boolAttr(account.Attributes, "account_matching_opt_out"):
+		return matchResultNoMatch, "OPTO", ""
+	case boolAttr(account.Attributes, "switched"):
+		return matchResultNoMatch, "CASS", ""
+	case stringAttr(account.Attributes, "secondary_identification") != stringAttr(check, "secondary_identification"):
+		return matchResultNoMatch, "IVCR", ""
+	}
+
+	quality, accountName := matchName(stringAttr(check, "name"), accountNames(account.Attributes))
+	if quality == nameNoMatch {
+		return matchResultNoMatch, "ANNM", ""
+	}
+
+	accountType := stringAttr(account.Attributes, "account_classification")
+	if accountType == "" {
+		accountType = "Personal"
+	}
+	switch {
+	case accountType != stringAttr(check, "account_type"):
+		return matchResultCloseMatch, accountTypeReason(accountType, quality), accountName
+	case quality == nameCloseMatch:
+		return matchResultCloseMatch, "MBAM", accountName
+	}
+	return matchResultMatch, "", ""
+}
+
+// accountTypeReason returns the reason code of a name matching an account of the other account type.
+func accountTypeReason(accountType string, quality int) string {
+	if accountType == "Business" {
+		if quality == nameMatch {
+			return "BANM"
+		}
+		return "BAMM"
+	}
+	if quality == nameMatch {
+		return "PANM"
+	}
+	return "PAMM"
+}
+
+// accountNames returns the name and the alternative names of the account.
+func accountNames(attributes map[string]json.RawMessage) []string {
+	var name, alternatives []string
+	_ = json.Unmarshal(attributes["name"], &name)
+	_ = json.Unmarshal(attributes["alternative_names"], &alternatives)
+
+	names := []string{strings.Join(name, " ")}
+	return append(names, alternatives...)
+}
+
+// matchName returns the best match quality of the name with the account names and the matched account name.
+func matchName(name string, accountNames []string) (int, string) {
+	best, bestName := nameNoMatch, ""
+	tokens := nameTokens(name)
+	for _, accountName := range accountNames {
+		accountTokens := nameTokens(accountName)
+		quality := nameNoMatch
+		switch {
+		case len(tokens) == 0 || len(accountTokens) == 0:
+		case strings.Join(tokens, " ") == strings.Join(accountTokens, " "):
+			quality = nameMatch
+		case tokens[len(tokens)-1] == accountTokens[len(accountTokens)-1] && tokens[0][0] == accountTokens[0][0]:
+			quality = nameCloseMatch
+		}
+		if quality > best {
+			best, bestName = quality, accountName
+		}
+	}
+	return best, bestName
+}
+
+// nameTokens returns the lower case words of the name without punctuation.
+func nameTokens(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+}
+
+func stringAttr(attributes map[string]json.RawMessage, name string) string {
+	var value string
+	_ = json.Unmarshal(attributes[name], &value)
+	return value
+}
+
+func boolAttr(attributes map[string]json.RawMessage, name string) bool {
+	var value bool
+	_ = json.Unmarshal(attributes[name], &value)
+	return value
+}
@@ -0,0 +1,73 @@
+package form3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+func mandateRules() []attributeRule {
+	return []attributeRule{
+		{name: "payment_scheme", required: true, re: regexp.MustCompile("^(BACS|SEPADD)$")},
+		{name: "reference", required: true, re: regexp.MustCompile("^.{1,35}$")},
+		{name: "signature_date", re: regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)},
+	}
+}
+
+// newMandates creates the mandates collection with its submissions.
+func newMandates() *collection {
+	mandates := newCollection("mandates", mandatesPath, getValidateRules(mandateRules()))
+	mandates.defaults = map[string]json.RawMessage{"status": json.RawMessage(`"pending"`)}
+	mandates.subResources = submissions("mandate_submissions")
+	return mandates
+}
+
+// newDirectDebits creates the direct debits collection with its returns and reversals.
+func newDirectDebits() *collection {
+	directDebits := newCollection("directdebits", directDebitsPath, getValidatePayment())
+	directDebits.incoming = true
+	directDebits.nest(returnsPath, subResource{
+		typ:      "directdebit_returns",
+		validate: getValidateRules(reasonRules("return_code", true)),
+	})
+	directDebits.nest(reversalsPath, subResource{
+		typ:      "directdebit_reversals",
+		validate: getValidateRules(reasonRules("reason", false)),
+	})
+	return directDebits
+}
+
+// SetMandateStatus simulates the scheme processing a submitted mandate by setting its status,
+// e.g. to "active". The version of the mandate is incremented.
+func (s *Server) SetMandateStatus(mandateID, status string) error {
+	value, _ := json.Marshal(status)
+	if !s.mandates.setAttribute(mandateID, "status", value) {
+		return fmt.Errorf("%w: mandate %s", ErrNotFound, mandateID)
+	}
+	return nil
+}
+
+// AddDirectDebit simulates the scheme delivering a direct debit collected from an account of the
+// organisation. The attributes (e.g. "amount", "currency", "mandate_reference") are stored as given.
+// It returns the id of the direct debit.
+func (s *Server) AddDirectDebit(orgID string, attributes map[string]interface{}) (string, error) {
+	attr := make(map[string]json.RawMessage, len(attributes))
+	for name, value := range attributes {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("attribute %s: %w", name, err)
+		}
+		attr[name] = raw
+	}
+
+	rec := &record{
+		Type:           s.directDebits.typ,
+		ID:             uuid.NewString(),
+		OrganisationID: orgID,
+		Attributes:     attr,
+	}
+	s.directDebits.add(rec)
+	return rec.ID, nil
+}
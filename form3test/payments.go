@@ -0,0 +1,124 @@
+package form3test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// path segments of the payment sub-resources
+const (
+	submissionsPath = "submissions"
+	admissionsPath  = "admissions"
+	returnsPath     = "returns"
+	reversalsPath   = "reversals"
+	recallsPath     = "recalls"
+)
+
+// ErrNotFound is returned by the Server methods simulating the scheme if the record does not exist.
+var ErrNotFound = errors.New("record does not exist")
+
+func paymentRules() []attributeRule {
+	return []attributeRule{
+		{name: "amount", required: true, re: regexp.MustCompile(`^\d+(\.\d+)?$`)},
+		{name: "currency", required: true, re: regexp.MustCompile("^[A-Z]{3}$")},
+		{name: "processing_date", re: regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)},
+	}
+}
+
+// getValidatePayment returns a validator mimicking the validation of the payment API.
+func getValidatePayment() validator {
+	return getValidateRules(paymentRules())
+}
+
+// reasonRules returns the rules of the return, reversal and recall reason codes.
+func reasonRules(name string, required bool) []attributeRule {
+	return []attributeRule{
+		{name: name, required: required, re: regexp.MustCompile("^[A-Z0-9]{4}$")},
+	}
+}
+
+func getValidateRules(rules []attributeRule) validator {
+	return func(attr map[string]json.RawMessage) []string {
+		return validateRules(rules, attr)
+	}
+}
+
+// submissions returns the submissions sub-resource of given type.
+func submissions(typ string) map[string]subResource {
+	return map[string]subResource{
+		submissionsPath: {
+			typ:      typ,
+			validate: acceptAll,
+			defaults: map[string]json.RawMessage{"status": json.RawMessage(`"accepted"`)},
+		},
+	}
+}
+
+// acceptAll is the validator of resources without client provided attributes.
+func acceptAll(map[string]json.RawMessage) []string {
+	return nil
+}
+
+// newPayments creates the payments collection with its submissions and admissions.
+func newPayments() *collection {
+	payments := newCollection("payments", paymentsPath, getValidatePayment())
+	payments.subResources = submissions("payment_submissions")
+	payments.nest(admissionsPath, subResource{
+		typ:      "payment_admissions",
+		validate: acceptAll,
+	})
+	payments.nest(returnsPath, subResource{
+		typ:      "returns",
+		validate: getValidateRules(reasonRules("return_code", true)),
+		nested:   submissions("return_submissions"),
+	})
+	payments.nest(reversalsPath, subResource{
+		typ:      "reversals",
+		validate: getValidateRules(reasonRules("reason", false)),
+		nested:   submissions("reversal_submissions"),
+	})
+	payments.nest(recallsPath, subResource{
+		typ:      "recalls",
+		validate: getValidateRules(reasonRules("reason", true)),
+		nested:   submissions("recall_submissions"),
+	})
+	return payments
+}
+
+// SetSubmissionStatus simulates the scheme processing a submitted payment by setting the status of the
+// submission, e.g. to "delivery_confirmed". The version of the submission is incremented.
+func (s *Server) SetSubmissionStatus(paymentID, submissionID, status string) error {
+	submissions, ok := s.payments.nested(paymentID, submissionsPath)
+	if !ok {
+		return fmt.Errorf("%w: payment %s", ErrNotFound, paymentID)
+	}
+	value, _ := json.Marshal(status)
+	if !submissions.setAttribute(submissionID, "status", value) {
+		return fmt.Errorf("%w: submission %s", ErrNotFound, submissionID)
+	}
+	return nil
+}
+
+// AdmitPayment simulates the scheme delivering an incoming payment by creating an admission with
+// the status (e.g. "confirmed") for the payment. It returns the id of the admission.
+func (s *Server) AdmitPayment(paymentID, status string) (string, error) {
+	orgID, ok := s.payments.organisationOf(paymentID)
+	if !ok {
+		return "", fmt.Errorf("%w: payment %s", ErrNotFound, paymentID)
+	}
+	admissions, _ := s.payments.nested(paymentID, admissionsPath)
+	value, _ := json.Marshal(status)
+
+	rec := &record{
+		Type:           admissions.typ,
+		ID:             uuid.NewString(),
+		OrganisationID: orgID,
+		Attributes:     map[string]json.RawMessage{"status": value},
+	}
+	admissions.add(rec)
+	return rec.ID, nil
+}
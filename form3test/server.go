@@ -0,0 +1,143 @@
+/*
+Package form3test provides an in-memory fake of the form3 API for hermetic tests.
+
+The fake speaks the same JSON:API envelope as the real API and mimics its behaviour for the supported
+resources (accounts, payments, mandates and direct debits): pagination links, version checks, 404/409
+semantics and validation errors. Confirmation of Payee checks are answered with the stored accounts.
+Every Server has its own isolated state, so tests can run in parallel each against their own server:
+
+	srv := form3test.NewServer()
+	defer srv.Close()
+
+	cl := form3.NewClient(srv.URL)
+
+The processing by the scheme can be simulated with SetSubmissionStatus, AdmitPayment, SetMandateStatus
+and AddDirectDebit.
+*/
+package form3test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/tehsphinx/form3/httpsig"
+)
+
+const (
+	accountsPath     = "/v1/organisation/accounts"
+	paymentsPath     = "/v1/transaction/payments"
+	mandatesPath     = "/v1/transaction/mandates"
+	directDebitsPath = "/v1/transaction/directdebits"
+
+	confirmationOfPayeePath = "/v1/services/confirmation-of-payee"
+)
+
+// Server is an in-memory fake of the form3 API. Use NewServer to create one.
+type Server struct {
+	*httptest.Server
+
+	accounts     *collection
+	payments     *collection
+	mandates     *collection
+	directDebits *collection
+	payeeChecks  *collection
+}
+
+// NewServer starts a new fake form3 API server with empty state. The server must be closed
+// with Close after use. Use one or more Option to further configure the server.
+func NewServer(opts ...Option) *Server {
+	options := &serverOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	srv := &Server{
+		accounts:     newCollection("accounts", accountsPath, getValidateAccount()),
+		payments:     newPayments(),
+		mandates:     newMandates(),
+		directDebits: newDirectDebits(),
+	}
+	srv.accounts.ignored = options.ignoredAttributes
+	srv.payeeChecks = newPayeeChecks(srv.accounts)
+
+	mux := http.NewServeMux()
+	mux.Handle(accountsPath, srv.accounts)
+	mux.Handle(accountsPath+"/", srv.accounts)
+	mux.Handle(paymentsPath, srv.payments)
+	mux.Handle(paymentsPath+"/", srv.payments)
+	mux.Handle(mandatesPath, srv.mandates)
+	mux.Handle(mandatesPath+"/", srv.mandates)
+	mux.Handle(directDebitsPath, srv.directDebits)
+	mux.Handle(directDebitsPath+"/", srv.directDebits)
+	mux.Handle(confirmationOfPayeePath, srv.payeeChecks)
+	mux.Handle(confirmationOfPayeePath+"/", srv.payeeChecks)
+
+	var handler http.Handler = mux
+	if options.verifier != nil {
+		handler = verifySignature(options.verifier, handler)
+	}
+
+	srv.Server = httptest.NewServer(handler)
+	return srv
+}
+
+// verifySignature rejects all requests without a valid signature.
+func verifySignature(verifier *httpsig.Verifier, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := verifier.Verify(r); err != nil {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AccountCount returns the number of accounts currently stored in the server.
+func (s *Server) AccountCount() int {
+	return s.accounts.count()
+}
+
+// PaymentCount returns the number of payments currently stored in the server.
+func (s *Server) PaymentCount() int {
+	return s.payments.count()
+}
+
+type envelope struct {
+	Data  *record           `json:"data"`
+	Links map[string]string `json:"links,omitempty"`
+}
+
+type listEnvelope struct {
+	Data  []*record         `json:"data"`
+	Links map[string]string `json:"links"`
+}
+
+type record struct {
+	Type           string                     `json:"type"`
+	ID             string                     `json:"id"`
+	OrganisationID string                     `json:"organisation_id"`
+	Version        *int                       `json:"version,omitempty"`
+	CreatedOn      time.Time                  `json:"created_on"`
+	ModifiedOn     time.Time                  `json:"modified_on"`
+	Attributes     map[string]json.RawMessage `json:"attributes"`
+}
+
+type apiError struct {
+	ErrorMessage string `json:"error_message"`
+	ErrorCode    string `json:"error_code,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, msgs ...string) {
+	writeJSON(w, status, apiError{
+		ErrorMessage: strings.Join(msgs, "\n"),
+	})
+}
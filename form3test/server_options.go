@@ -0,0 +1,41 @@
+package form3test
+
+import (
+	"crypto"
+
+	"github.com/tehsphinx/form3/httpsig"
+)
+
+// Option defines an optional parameter for creating a form3test.NewServer server.
+type Option func(opts *serverOptions)
+
+type serverOptions struct {
+	ignoredAttributes map[string]bool
+	verifier          *httpsig.Verifier
+}
+
+// WithoutAttributes makes the server silently drop the given attributes of records instead of storing them.
+// It can be used to mimic API versions not supporting these attributes (e.g. the `name` and `alternative_names`
+// attributes in the accountapi image used in the docker-compose setup).
+func WithoutAttributes(names ...string) Option {
+	return func(opts *serverOptions) {
+		if opts.ignoredAttributes == nil {
+			opts.ignoredAttributes = map[string]bool{}
+		}
+		for _, name := range names {
+			opts.ignoredAttributes[name] = true
+		}
+	}
+}
+
+// WithSignatureVerification makes the server reject all requests that are not signed with the private
+// key belonging to the given public key (see form3.WithRequestSigning). Can be used multiple times to
+// accept multiple keys.
+func WithSignatureVerification(keyID string, publicKey crypto.PublicKey) Option {
+	return func(opts *serverOptions) {
+		if opts.verifier == nil {
+			opts.verifier = httpsig.NewVerifier()
+		}
+		opts.verifier.AddKey(keyID, publicKey)
+	}
+}
@@ -0,0 +1,197 @@
+package form3test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+const orgID = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+
+func createAccount(t *testing.T, srv *Server, uid, attributes string) *http.Response {
+	t.Helper()
+
+	body := fmt.Sprintf(`{"data":{"type":"accounts","id":"%s","organisation_id":"%s","attributes":%s}}`,
+		uid, orgID, attributes)
+	resp, err := http.Post(srv.URL+accountsPath, "application/json", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestServer_create(t *testing.T) {
+	tests := []struct {
+		name        string
+		uid         string
+		attributes  string
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:       "valid account",
+			uid:        "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			attributes: `{"country":"GB","bank_id":"400300"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:        "duplicate id",
+			uid:         "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			attributes:  `{"country":"GB"}`,
+			wantStatus:  http.StatusConflict,
+			wantMessage: "duplicate constraint",
+		},
+		{
+			name:        "invalid id",
+			uid:         "not-a-uuid",
+			attributes:  `{"country":"GB"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "id in body must be of type uuid",
+		},
+		{
+			name:        "missing country",
+			uid:         "bd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			attributes:  `{"bank_id":"400300"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "country in body is required",
+		},
+	}
+
+	srv := NewServer()
+	defer srv.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			resp := createAccount(t, srv, tt.uid, tt.attributes)
+			defer resp.Body.Close()
+			assert.Equal(resp.StatusCode, tt.wantStatus)
+
+			if tt.wantMessage == "" {
+				return
+			}
+			var apiErr apiError
+			assert.NoErr(json.NewDecoder(resp.Body).Decode(&apiErr))
+			assert.True(strings.Contains(apiErr.ErrorMessage, tt.wantMessage))
+		})
+	}
+	is.New(t).Equal(srv.AccountCount(), 1)
+}
+
+func TestServer_listLinks(t *testing.T) {
+	assert := is.New(t)
+
+	srv := NewServer()
+	defer srv.Close()
+
+	uids := []string{
+		"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		"bd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		"cd27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+	}
+	for i, uid := range uids {
+		country := "GB"
+		if i == 1 {
+			country = "DE"
+		}
+		resp := createAccount(t, srv, uid, `{"country":"`+country+`"}`)
+		resp.Body.Close()
+	}
+
+	list := func(query string) listEnvelope {
+		resp, err := http.Get(srv.URL + accountsPath + "?" + query)
+		assert.NoErr(err)
+		defer resp.Body.Close()
+
+		var env listEnvelope
+		assert.NoErr(json.NewDecoder(resp.Body).Decode(&env))
+		return env
+	}
+
+	first := list("page[size]=2")
+	assert.Equal(len(first.Data), 2)
+	assert.Equal(first.Data[0].ID, uids[0])
+	_, hasPrev := first.Links["prev"]
+	assert.True(!hasPrev)
+
+	second := list(strings.TrimPrefix(first.Links["next"], accountsPath+"?"))
+	assert.Equal(len(second.Data), 1)
+	assert.Equal(second.Data[0].ID, uids[2])
+	_, hasNext := second.Links["next"]
+	assert.True(!hasNext)
+
+	filtered := list("filter[country]=GB")
+	assert.Equal(len(filtered.Data), 2)
+	assert.Equal(filtered.Data[1].ID, uids[2])
+}
+
+func TestServer_nested(t *testing.T) {
+	assert := is.New(t)
+
+	srv := NewServer()
+	defer srv.Close()
+
+	const paymentID = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	body := fmt.Sprintf(`{"data":{"type":"payments","id":"%s","organisation_id":"%s",`+
+		`"attributes":{"amount":"1.00","currency":"GBP"}}}`, paymentID, orgID)
+	resp, err := http.Post(srv.URL+paymentsPath, "application/json", bytes.NewBufferString(body))
+	assert.NoErr(err)
+	resp.Body.Close()
+	assert.Equal(resp.StatusCode, http.StatusCreated)
+
+	// sub-resources of unknown payments do not exist
+	resp, err = http.Get(srv.URL + paymentsPath + "/bd27e265-9605-4b4b-a0e5-3003ea9cc4dc/submissions")
+	assert.NoErr(err)
+	resp.Body.Close()
+	assert.Equal(resp.StatusCode, http.StatusNotFound)
+
+	const submissionID = "cd27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	body = fmt.Sprintf(`{"data":{"type":"payment_submissions","id":"%s","organisation_id":"%s","attributes":{}}}`,
+		submissionID, orgID)
+	submissionsURL := srv.URL + paymentsPath + "/" + paymentID + "/submissions"
+	resp, err = http.Post(submissionsURL, "application/json", bytes.NewBufferString(body))
+	assert.NoErr(err)
+	resp.Body.Close()
+	assert.Equal(resp.StatusCode, http.StatusCreated)
+
+	assert.NoErr(srv.SetSubmissionStatus(paymentID, submissionID, "delivery_confirmed"))
+	assert.True(errors.Is(srv.SetSubmissionStatus(paymentID, paymentID, "delivery_confirmed"), ErrNotFound))
+
+	resp, err = http.Get(submissionsURL + "/" + submissionID)
+	assert.NoErr(err)
+	defer resp.Body.Close()
+	var env envelope
+	assert.NoErr(json.NewDecoder(resp.Body).Decode(&env))
+	assert.Equal(string(env.Data.Attributes["status"]), `"delivery_confirmed"`)
+	assert.Equal(*env.Data.Version, 1)
+	assert.Equal(env.Links["self"], paymentsPath+"/"+paymentID+"/submissions/"+submissionID)
+}
+
+func TestServer_incoming(t *testing.T) {
+	assert := is.New(t)
+
+	srv := NewServer()
+	defer srv.Close()
+
+	// direct debits are received from the scheme, they can't be created by clients
+	body := fmt.Sprintf(`{"data":{"type":"directdebits","id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",`+
+		`"organisation_id":"%s","attributes":{"amount":"1.00","currency":"GBP"}}}`, orgID)
+	resp, err := http.Post(srv.URL+directDebitsPath, "application/json", bytes.NewBufferString(body))
+	assert.NoErr(err)
+	resp.Body.Close()
+	assert.Equal(resp.StatusCode, http.StatusMethodNotAllowed)
+
+	uid, err := srv.AddDirectDebit(orgID, map[string]interface{}{"amount": "1.00", "currency": "GBP"})
+	assert.NoErr(err)
+	resp, err = http.Get(srv.URL + directDebitsPath + "/" + uid)
+	assert.NoErr(err)
+	resp.Body.Close()
+	assert.Equal(resp.StatusCode, http.StatusOK)
+}
@@ -0,0 +1,146 @@
+/*
+Package httpsig implements signing and verifying http requests according to draft-cavage HTTP Signatures
+(https://tools.ietf.org/html/draft-cavage-http-signatures-12) as used by the form3 API.
+
+Requests are signed over the `(request-target)`, `host`, `date` and `digest` headers. The signature is sent in
+the Authorization header:
+
+	Authorization: Signature keyId="...",algorithm="rsa-sha256",headers="(request-target) host date digest",signature="..."
+
+RSA (rsa-sha256), ECDSA (ecdsa-sha256) and Ed25519 (ed25519) keys are supported.
+*/
+package httpsig
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// supported signature algorithms
+const (
+	AlgorithmRSASHA256   = "rsa-sha256"
+	AlgorithmECDSASHA256 = "ecdsa-sha256"
+	AlgorithmEd25519     = "ed25519"
+)
+
+const (
+	headerRequestTarget = "(request-target)"
+	authScheme          = "Signature "
+)
+
+// ErrUnsupportedKey is returned if the type of a key is not supported.
+var ErrUnsupportedKey = errors.New("unsupported key type")
+
+// signedHeaders lists the headers covered by the signature in the order they are signed.
+func signedHeaders() []string {
+	return []string{headerRequestTarget, "host", "date", "digest"}
+}
+
+// Signer signs http requests. Create one with NewSigner.
+type Signer struct {
+	keyID string
+	key   crypto.Signer
+	now   func() time.Time
+}
+
+// NewSigner creates a new signer signing requests with the given private key. The key id
+// is sent along with the signature for the server to look up the matching public key.
+func NewSigner(keyID string, key crypto.Signer) *Signer {
+	return &Signer{
+		keyID: keyID,
+		key:   key,
+		now:   time.Now,
+	}
+}
+
+// Sign adds the Date, Digest and Authorization headers to the request. The body must be
+// the body the request is sent with.
+func (s *Signer) Sign(req *http.Request, body []byte) error {
+	algorithm, err := algorithmFor(s.key.Public())
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Date", s.now().UTC().Format(http.TimeFormat))
+	req.Header.Set("Digest", digest(body))
+
+	headers := signedHeaders()
+	signature, err := s.sign(algorithm, signingString(req, requestTarget(req), headers))
+	if err != nil {
+		return fmt.Errorf("signing request failed: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf(`%skeyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		authScheme, s.keyID, algorithm, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+	return nil
+}
+
+func (s *Signer) sign(algorithm, message string) ([]byte, error) {
+	if algorithm == AlgorithmEd25519 {
+		// ed25519 signs the message itself, not a hash of it
+		return s.key.Sign(rand.Reader, []byte(message), crypto.Hash(0))
+	}
+
+	hash := sha256.Sum256([]byte(message))
+	return s.key.Sign(rand.Reader, hash[:], crypto.SHA256)
+}
+
+func algorithmFor(key crypto.PublicKey) (string, error) {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return AlgorithmRSASHA256, nil
+	case *ecdsa.PublicKey:
+		return AlgorithmECDSASHA256, nil
+	case ed25519.PublicKey:
+		return AlgorithmEd25519, nil
+	}
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+}
+
+func digest(body []byte) string {
+	hash := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func requestTarget(req *http.Request) string {
+	uri := req.RequestURI
+	if uri == "" {
+		uri = req.URL.RequestURI()
+	}
+	return strings.ToLower(req.Method) + " " + uri
+}
+
+func host(req *http.Request) string {
+	if req.Host != "" {
+		return req.Host
+	}
+	return req.URL.Host
+}
+
+// signingString builds the string to be signed from the given headers.
+func signingString(req *http.Request, target string, headers []string) string {
+	lines := make([]string, 0, len(headers))
+	for _, name := range headers {
+		var value string
+		switch name {
+		case headerRequestTarget:
+			value = target
+		case "host":
+			value = host(req)
+		default:
+			value = req.Header.Get(name)
+		}
+		lines = append(lines, name+": "+value)
+	}
+	return strings.Join(lines, "\n")
+}
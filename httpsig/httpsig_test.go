@@ -0,0 +1,136 @@
+package httpsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func generateKeys(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]crypto.Signer{
+		AlgorithmRSASHA256:   rsaKey,
+		AlgorithmECDSASHA256: ecdsaKey,
+		AlgorithmEd25519:     ed25519Key,
+	}
+}
+
+// serverRequest signs a client request and converts it to the request a server would receive.
+func serverRequest(t *testing.T, signer *Signer, body string, tamper func(r *http.Request)) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "http://api.example.com/v1/organisation/accounts?page%5Bsize%5D=1",
+		strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signer.Sign(req, []byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(req.Header.Get("Authorization"), `Signature keyId="`) {
+		t.Fatal("authorization header missing")
+	}
+
+	srvReq := httptest.NewRequest(req.Method, req.URL.String(), bytes.NewBufferString(body))
+	srvReq.RequestURI = req.URL.RequestURI()
+	srvReq.Header = req.Header
+	if tamper != nil {
+		tamper(srvReq)
+	}
+	return srvReq
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyID   string
+		tamper  func(r *http.Request)
+		wantErr error
+	}{
+		{
+			name:  "valid signature",
+			keyID: "key-1",
+		},
+		{
+			name:    "unknown key",
+			keyID:   "key-2",
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:  "missing signature",
+			keyID: "key-1",
+			tamper: func(r *http.Request) {
+				r.Header.Del("Authorization")
+			},
+			wantErr: ErrMissingSignature,
+		},
+		{
+			name:  "tampered body",
+			keyID: "key-1",
+			tamper: func(r *http.Request) {
+				r.Body = http.NoBody
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:  "tampered date",
+			keyID: "key-1",
+			tamper: func(r *http.Request) {
+				r.Header.Set("Date", "Mon, 02 Jan 2006 15:04:05 GMT")
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:  "tampered target",
+			keyID: "key-1",
+			tamper: func(r *http.Request) {
+				r.RequestURI = "/v1/organisation/accounts"
+			},
+			wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for algorithm, key := range generateKeys(t) {
+		algo, err := algorithmFor(key.Public())
+		is.New(t).NoErr(err)
+		is.New(t).Equal(algo, algorithm)
+
+		verifier := NewVerifier()
+		verifier.AddKey("key-1", key.Public())
+
+		for _, tt := range tests {
+			t.Run(algorithm+"/"+tt.name, func(t *testing.T) {
+				assert := is.New(t)
+
+				req := serverRequest(t, NewSigner(tt.keyID, key), `{"data":{}}`, tt.tamper)
+				err := verifier.Verify(req)
+				assert.True(errors.Is(err, tt.wantErr))
+			})
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package httpsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// verification errors
+var (
+	ErrMissingSignature = errors.New("request is not signed")
+	ErrInvalidSignature = errors.New("request signature is invalid")
+	ErrUnknownKey       = errors.New("signing key is unknown")
+)
+
+// Verifier verifies signatures of http requests against a set of known public keys.
+// Create one with NewVerifier. It is safe for concurrent use.
+type Verifier struct {
+	m    sync.RWMutex
+	keys map[string]crypto.PublicKey
+}
+
+// NewVerifier creates a new verifier without any known keys. Add keys with AddKey.
+func NewVerifier() *Verifier {
+	return &Verifier{
+		keys: map[string]crypto.PublicKey{},
+	}
+}
+
+// AddKey registers a public key for the given key id.
+func (s *Verifier) AddKey(keyID string, key crypto.PublicKey) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.keys[keyID] = key
+}
+
+// Verify checks the signature and digest of a request received by a server. The body of
+// the request is read and replaced, so it can be read again by the handler.
+func (s *Verifier) Verify(req *http.Request) error {
+	params, err := parseAuthorization(req.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+
+	s.m.RLock()
+	key, ok := s.keys[params["keyId"]]
+	s.m.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownKey, params["keyId"])
+	}
+
+	algorithm, err := algorithmFor(key)
+	if err != nil {
+		return err
+	}
+	if params["algorithm"] != "" && params["algorithm"] != algorithm {
+		return fmt.Errorf("%w: algorithm %q does not match key", ErrInvalidSignature, params["algorithm"])
+	}
+
+	headers := strings.Fields(params["headers"])
+	if err := checkCoverage(headers); err != nil {
+		return err
+	}
+	if err := verifyDigest(req); err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	message := signingString(req, requestTarget(req), headers)
+	if !verifySignature(key, message, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// parseAuthorization reads the parameters of a `Signature` Authorization header.
+func parseAuthorization(header string) (map[string]string, error) {
+	if !strings.HasPrefix(header, authScheme) {
+		return nil, ErrMissingSignature
+	}
+
+	params := map[string]string{}
+	for _, param := range strings.Split(strings.TrimPrefix(header, authScheme), ",") {
+		parts := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%w: malformed parameter %q", ErrInvalidSignature, param)
+		}
+		params[parts[0]] = strings.Trim(parts[1], `"`)
+	}
+	return params, nil
+}
+
+// checkCoverage makes sure all required headers are covered by the signature.
+func checkCoverage(headers []string) error {
+	covered := map[string]bool{}
+	for _, name := range headers {
+		covered[name] = true
+	}
+	for _, name := range signedHeaders() {
+		if !covered[name] {
+			return fmt.Errorf("%w: header %q is not signed", ErrInvalidSignature, name)
+		}
+	}
+	return nil
+}
+
+func verifyDigest(req *http.Request) error {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		if err != nil {
+			return fmt.Errorf("reading request body failed: %w", err)
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if req.Header.Get("Digest") != digest(body) {
+		return fmt.Errorf("%w: digest does not match body", ErrInvalidSignature)
+	}
+	return nil
+}
+
+func verifySignature(key crypto.PublicKey, message string, signature []byte) bool {
+	hash := sha256.Sum256([]byte(message))
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, hash[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, []byte(message), signature)
+	}
+	return false
+}
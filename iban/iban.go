@@ -0,0 +1,210 @@
+/*
+Package iban validates, parses and generates International Bank Account Numbers (ISO 13616).
+
+An IBAN is valid if its length and BBAN (Basic Bank Account Number) structure match the SWIFT IBAN
+registry entry of its country and the check digits pass the mod-97 check (ISO 7064).
+
+For a number of countries the BBAN is additionally split into the bank id and account number as they
+are used by the form3 account API:
+
+	ib, err := iban.Parse("GB29 NWBK 6016 1331 9268 19")
+	// ib.BankCode: NWBK, ib.BankID: 601613, ib.AccountNumber: 31926819
+
+	code, err := iban.Generate("GB", "601613", "31926819", iban.WithBankCode("NWBK"))
+	// code: GB29NWBK60161331926819
+*/
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	countryCodeLength = 2
+	checkDigitsLength = 2
+	// groupSize is the number of characters per group in the print format.
+	groupSize = 4
+)
+
+// validation errors
+var (
+	ErrInvalidCountry     = errors.New("unknown iban country")
+	ErrInvalidLength      = errors.New("invalid iban length")
+	ErrInvalidFormat      = errors.New("bban does not match the structure of the country")
+	ErrInvalidCheckDigits = errors.New("invalid iban check digits")
+	// ErrUnsupportedCountry is returned by Generate if the mapping of bank id and account number
+	// to the BBAN of the country is not known.
+	ErrUnsupportedCountry = errors.New("generating ibans is not supported for the country")
+	// ErrBankCodeRequired is returned by Generate if the BBAN of the country contains a bank code
+	// that is not part of the bank id. See WithBankCode.
+	ErrBankCodeRequired = errors.New("bank code required")
+)
+
+// IBAN is a parsed International Bank Account Number.
+type IBAN struct {
+	// Country is the ISO 3166-1 alpha-2 country code.
+	Country string
+	// CheckDigits are the two check digits following the country code.
+	CheckDigits string
+	// BBAN is the country specific Basic Bank Account Number.
+	BBAN string
+
+	// BankCode is the institution code of countries where it is not part of the bank id
+	// (e.g. first 4 characters of the BIC in GB).
+	BankCode string
+	// BankID and AccountNumber as they are used by the account API. They are only set
+	// for countries with a known mapping (see Generate).
+	BankID        string
+	AccountNumber string
+}
+
+// String returns the IBAN in electronic format without spaces.
+func (s *IBAN) String() string {
+	return s.Country + s.CheckDigits + s.BBAN
+}
+
+// Print returns the IBAN in print format: groups of four characters separated by spaces.
+func (s *IBAN) Print() string {
+	code := s.String()
+	groups := make([]string, 0, len(code)/groupSize+1)
+	for len(code) > groupSize {
+		groups = append(groups, code[:groupSize])
+		code = code[groupSize:]
+	}
+	return strings.Join(append(groups, code), " ")
+}
+
+// Normalize removes spaces from the IBAN and converts it to upper case.
+func Normalize(iban string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(iban), ""))
+}
+
+// Validate checks if the IBAN is valid. Spaces and lower case letters are accepted.
+func Validate(iban string) error {
+	_, err := Parse(iban)
+	return err
+}
+
+// Parse validates the IBAN and splits it into its parts. Spaces and lower case letters are accepted.
+func Parse(iban string) (*IBAN, error) {
+	code := Normalize(iban)
+	if len(code) < countryCodeLength+checkDigitsLength {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLength, len(code))
+	}
+
+	country := code[:countryCodeLength]
+	bbanStructure, ok := structureOf(country)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCountry, country)
+	}
+	if length := countryCodeLength + checkDigitsLength + bbanStructure.length(); len(code) != length {
+		return nil, fmt.Errorf("%w: %d instead of %d characters for %s", ErrInvalidLength, len(code), length, country)
+	}
+
+	bban := code[countryCodeLength+checkDigitsLength:]
+	if !bbanStructure.matches(bban) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFormat, country)
+	}
+	if !isDigits(code[countryCodeLength:countryCodeLength+checkDigitsLength]) || mod97(bban+code[:4]) != 1 {
+		return nil, ErrInvalidCheckDigits
+	}
+
+	ib := &IBAN{
+		Country:     country,
+		CheckDigits: code[countryCodeLength : countryCodeLength+checkDigitsLength],
+		BBAN:        bban,
+	}
+	if l, ok := countryLayouts[country]; ok {
+		ib.BankCode, ib.BankID, ib.AccountNumber = l.split(bban)
+	}
+	return ib, nil
+}
+
+// GenerateOption configures the generation of an IBAN.
+type GenerateOption func(*generateOptions)
+
+type generateOptions struct {
+	bankCode string
+}
+
+// WithBankCode sets the institution code for countries where it is part of the BBAN, but not of the
+// bank id (GB, IE: first 4 characters of the BIC; NL: bank code).
+func WithBankCode(code string) GenerateOption {
+	return func(opts *generateOptions) {
+		opts.bankCode = code
+	}
+}
+
+// Generate creates an IBAN from the bank id and account number as they are used by the account API.
+// Account numbers shorter than required by the country are padded with leading zeros.
+// National check digits are calculated where required.
+func Generate(country, bankID, accountNumber string, options ...GenerateOption) (string, error) {
+	var opts generateOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	l, ok := countryLayouts[country]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCountry, country)
+	}
+	if l.bankCode != 0 && opts.bankCode == "" {
+		return "", fmt.Errorf("%w: %s", ErrBankCodeRequired, country)
+	}
+	if len(accountNumber) < l.account {
+		accountNumber = strings.Repeat("0", l.account-len(accountNumber)) + accountNumber
+	}
+
+	bankCode := strings.ToUpper(opts.bankCode)
+	if len(bankCode) != l.bankCode || len(bankID) != l.bankID || len(accountNumber) != l.account ||
+		!isAlphanumeric(bankCode+bankID+accountNumber) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidFormat, country)
+	}
+
+	bban := l.join(bankCode, bankID, accountNumber)
+	if bbanStructure, _ := structureOf(country); !bbanStructure.matches(bban) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidFormat, country)
+	}
+	check := 98 - mod97(bban+country+"00")
+	return country + twoDigits(check) + bban, nil
+}
+
+// mod97 calculates the remainder of the numeric representation of the value divided by 97.
+// Letters are replaced by two digits (A = 10, ..., Z = 35).
+func mod97(value string) int64 {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= 'A' && r <= 'Z' {
+			fmt.Fprintf(&b, "%d", r-'A'+10)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	n, ok := new(big.Int).SetString(b.String(), 10)
+	if !ok {
+		return -1
+	}
+	return n.Mod(n, big.NewInt(97)).Int64()
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(value string) bool {
+	for _, r := range value {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package iban
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		iban    string
+		want    *IBAN
+		wantErr error
+	}{
+		{
+			name: "GB",
+			iban: "GB29 NWBK 6016 1331 9268 19",
+			want: &IBAN{Country: "GB", CheckDigits: "29", BBAN: "NWBK60161331926819",
+				BankCode: "NWBK", BankID: "601613", AccountNumber: "31926819"},
+		},
+		{
+			name: "DE lower case",
+			iban: "de89370400440532013000",
+			want: &IBAN{Country: "DE", CheckDigits: "89", BBAN: "370400440532013000",
+				BankID: "37040044", AccountNumber: "0532013000"},
+		},
+		{
+			name: "FR",
+			iban: "FR1420041010050500013M02606",
+			want: &IBAN{Country: "FR", CheckDigits: "14", BBAN: "20041010050500013M02606",
+				BankID: "2004101005", AccountNumber: "0500013M026"},
+		},
+		{
+			name: "ES",
+			iban: "ES9121000418450200051332",
+			want: &IBAN{Country: "ES", CheckDigits: "91", BBAN: "21000418450200051332",
+				BankID: "21000418", AccountNumber: "0200051332"},
+		},
+		{
+			name: "IT",
+			iban: "IT60X0542811101000000123456",
+			want: &IBAN{Country: "IT", CheckDigits: "60", BBAN: "X0542811101000000123456",
+				BankID: "0542811101", AccountNumber: "000000123456"},
+		},
+		{
+			name: "NL",
+			iban: "NL91ABNA0417164300",
+			want: &IBAN{Country: "NL", CheckDigits: "91", BBAN: "ABNA0417164300",
+				BankCode: "ABNA", AccountNumber: "0417164300"},
+		},
+		{
+			name: "country without layout",
+			iban: "NO9386011117947",
+			want: &IBAN{Country: "NO", CheckDigits: "93", BBAN: "86011117947"},
+		},
+		{
+			name:    "wrong check digits",
+			iban:    "GB28NWBK60161331926819",
+			wantErr: ErrInvalidCheckDigits,
+		},
+		{
+			name:    "wrong length",
+			iban:    "GB29NWBK6016133192681",
+			wantErr: ErrInvalidLength,
+		},
+		{
+			name:    "wrong structure",
+			iban:    "GB29NWB160161331926819",
+			wantErr: ErrInvalidFormat,
+		},
+		{
+			name:    "unknown country",
+			iban:    "US29NWBK60161331926819",
+			wantErr: ErrInvalidCountry,
+		},
+		{
+			name:    "too short",
+			iban:    "GB",
+			wantErr: ErrInvalidLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			got, err := Parse(tt.iban)
+			assert.True(errors.Is(err, tt.wantErr))
+			assert.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// examples of the SWIFT IBAN registry
+	ibans := []string{
+		"BI4210000100010000332045181", "BY13NBRB3600900000002Z00AB00", "DJ2100010000000154000100186",
+		"FK88SC123456789012", "HN88CABF00000000000250005469", "IQ98NBIQ850123456789012",
+		"LC55HEMM000100010012001200023015", "LY83002048000020100120361", "MN121234123456789123",
+		"MR1300020001010000123456753", "NI45BAPR00000013000003558124", "OM810180000001299123456",
+		"RU0304452522540817810538091310419", "SC18SSCB11010000000000001497USD", "SD2129010501234001",
+		"SO211000001001000100141", "ST23000100010051845310146", "SV62CENR00000000000000700025",
+		"TL380080012345678910157", "UA213223130000026007233566001", "VA59001123000012345678",
+		"YE15CBYE0001018861234567891234",
+	}
+	for _, iban := range ibans {
+		t.Run(iban[:2], func(t *testing.T) {
+			is.New(t).NoErr(Validate(iban))
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name          string
+		country       string
+		bankID        string
+		accountNumber string
+		options       []GenerateOption
+		want          string
+		wantErr       error
+	}{
+		{name: "GB", country: "GB", bankID: "601613", accountNumber: "31926819",
+			options: []GenerateOption{WithBankCode("NWBK")}, want: "GB29NWBK60161331926819"},
+		{name: "DE padded account", country: "DE", bankID: "37040044", accountNumber: "532013000",
+			want: "DE89370400440532013000"},
+		{name: "FR with RIB key", country: "FR", bankID: "2004101005", accountNumber: "0500013M026",
+			want: "FR1420041010050500013M02606"},
+		{name: "ES with control digits", country: "ES", bankID: "21000418", accountNumber: "0200051332",
+			want: "ES9121000418450200051332"},
+		{name: "IT with CIN", country: "IT", bankID: "0542811101", accountNumber: "000000123456",
+			want: "IT60X0542811101000000123456"},
+		{name: "BE with check digits", country: "BE", bankID: "539", accountNumber: "0075470",
+			want: "BE68539007547034"},
+		{name: "PT with check digits", country: "PT", bankID: "00020123", accountNumber: "12345678901",
+			want: "PT50000201231234567890154"},
+		{name: "NL", country: "NL", accountNumber: "0417164300",
+			options: []GenerateOption{WithBankCode("abna")}, want: "NL91ABNA0417164300"},
+		{name: "CH", country: "CH", bankID: "00762", accountNumber: "011623852957", want: "CH9300762011623852957"},
+		{name: "GB without bank code", country: "GB", bankID: "601613", accountNumber: "31926819",
+			wantErr: ErrBankCodeRequired},
+		{name: "invalid bank id", country: "DE", bankID: "3704004A", accountNumber: "0532013000",
+			wantErr: ErrInvalidFormat},
+		{name: "account number too long", country: "DE", bankID: "37040044", accountNumber: "05320130001",
+			wantErr: ErrInvalidFormat},
+		{name: "unsupported country", country: "NO", bankID: "8601", accountNumber: "1117947",
+			wantErr: ErrUnsupportedCountry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			got, err := Generate(tt.country, tt.bankID, tt.accountNumber, tt.options...)
+			assert.True(errors.Is(err, tt.wantErr))
+			assert.Equal(got, tt.want)
+			if err == nil {
+				assert.NoErr(Validate(got))
+			}
+		})
+	}
+}
+
+func TestIBAN_Print(t *testing.T) {
+	assert := is.New(t)
+
+	ib, err := Parse("GB29NWBK60161331926819")
+	assert.NoErr(err)
+	assert.Equal(ib.Print(), "GB29 NWBK 6016 1331 9268 19")
+	assert.Equal(ib.String(), "GB29NWBK60161331926819")
+}
+
+func TestLength(t *testing.T) {
+	assert := is.New(t)
+
+	l, ok := Length("DE")
+	assert.True(ok)
+	assert.Equal(l, 22)
+
+	_, ok = Length("US")
+	assert.True(!ok)
+
+	// the layouts must cover the full BBAN of their country
+	for country, layout := range layouts() {
+		s, ok := structureOf(country)
+		assert.True(ok)
+		assert.Equal(layout.bankCode+layout.bankID+layout.account+layout.checkLen, s.length())
+	}
+}
@@ -0,0 +1,194 @@
+package iban
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// checkPosition defines where the national check digits are located in a BBAN.
+type checkPosition int
+
+const (
+	noCheck checkPosition = iota
+	// checkBeforeBankID: check digits precede the bank id (e.g. Italian CIN)
+	checkBeforeBankID
+	// checkBeforeAccount: check digits are located between bank id and account number (e.g. Spain)
+	checkBeforeAccount
+	// checkAfterAccount: check digits follow the account number (e.g. France)
+	checkAfterAccount
+)
+
+// layout maps a BBAN to the bank id and account number as used by the account API.
+// The BBAN is composed of: bank code, bank id and account number, with optional national
+// check digits at the given position.
+type layout struct {
+	// bankCode is the length of the institution code (e.g. first 4 letters of the BIC in GB).
+	bankCode int
+	bankID   int
+	account  int
+
+	checkPos checkPosition
+	checkLen int
+	// check calculates the national check digits.
+	check func(bankID, account string) string
+}
+
+// countryLayouts holds the layouts by country. Built once as the layouts are static.
+var countryLayouts = layouts() //nolint:gochecknoglobals // read only after initialisation
+
+// layouts returns the layouts of the countries with a known mapping to bank id and account number.
+func layouts() map[string]layout {
+	frCheck := layout{bankID: 10, account: 11, checkPos: checkAfterAccount, checkLen: 2, check: ribKey}
+	return map[string]layout{
+		"AT": {bankID: 5, account: 11},
+		"BE": {bankID: 3, account: 7, checkPos: checkAfterAccount, checkLen: 2, check: belgianCheck},
+		"CH": {bankID: 5, account: 12},
+		"DE": {bankID: 8, account: 10},
+		"ES": {bankID: 8, account: 10, checkPos: checkBeforeAccount, checkLen: 2, check: spanishCheck},
+		"FR": frCheck,
+		"GB": {bankCode: 4, bankID: 6, account: 8},
+		"GR": {bankID: 7, account: 16},
+		"IE": {bankCode: 4, bankID: 6, account: 8},
+		"IT": {bankID: 10, account: 12, checkPos: checkBeforeBankID, checkLen: 1, check: italianCIN},
+		"LI": {bankID: 5, account: 12},
+		"LU": {bankID: 3, account: 13},
+		"MC": frCheck,
+		"NL": {bankCode: 4, account: 10},
+		"PL": {bankID: 8, account: 16},
+		"PT": {bankID: 8, account: 11, checkPos: checkAfterAccount, checkLen: 2, check: portugueseCheck},
+	}
+}
+
+// split splits a BBAN into its parts. The BBAN must match the structure of the country.
+func (s layout) split(bban string) (bankCode, bankID, account string) {
+	var pos int
+	next := func(l int) string {
+		part := bban[pos : pos+l]
+		pos += l
+		return part
+	}
+
+	bankCode = next(s.bankCode)
+	if s.checkPos == checkBeforeBankID {
+		next(s.checkLen)
+	}
+	bankID = next(s.bankID)
+	if s.checkPos == checkBeforeAccount {
+		next(s.checkLen)
+	}
+	account = next(s.account)
+	return bankCode, bankID, account
+}
+
+// join builds a BBAN from its parts calculating the national check digits if required.
+func (s layout) join(bankCode, bankID, account string) string {
+	var check string
+	if s.check != nil {
+		check = s.check(bankID, account)
+	}
+
+	var b strings.Builder
+	b.WriteString(bankCode)
+	if s.checkPos == checkBeforeBankID {
+		b.WriteString(check)
+	}
+	b.WriteString(bankID)
+	if s.checkPos == checkBeforeAccount {
+		b.WriteString(check)
+	}
+	b.WriteString(account)
+	if s.checkPos == checkAfterAccount {
+		b.WriteString(check)
+	}
+	return b.String()
+}
+
+// ribKey calculates the French RIB key.
+func ribKey(bankID, account string) string {
+	// letters of the account number are replaced by digits as defined for the RIB
+	const letterDigits = "12345678912345678923456789"
+	digits := strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return rune(letterDigits[r-'A'])
+		}
+		return r
+	}, account)
+
+	bank, _ := strconv.ParseInt(bankID[:5], 10, 64)
+	branch, _ := strconv.ParseInt(bankID[5:], 10, 64)
+	acc, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return "00"
+	}
+
+	sum := new(big.Int).Mul(acc, big.NewInt(3))
+	sum.Add(sum, big.NewInt(89*bank+15*branch))
+	key := 97 - sum.Mod(sum, big.NewInt(97)).Int64()
+	return twoDigits(key)
+}
+
+// belgianCheck calculates the check digits of a Belgian account number.
+func belgianCheck(bankID, account string) string {
+	n, _ := strconv.ParseInt(bankID+account, 10, 64)
+	check := n % 97
+	if check == 0 {
+		check = 97
+	}
+	return twoDigits(check)
+}
+
+// spanishCheck calculates the two control digits of a Spanish account (CCC).
+func spanishCheck(bankID, account string) string {
+	return spanishDigit("00"+bankID) + spanishDigit(account)
+}
+
+func spanishDigit(value string) string {
+	weights := [...]int{1, 2, 4, 8, 5, 10, 9, 7, 3, 6}
+	var sum int
+	for i, r := range value {
+		sum += int(r-'0') * weights[i]
+	}
+	switch digit := 11 - sum%11; digit {
+	case 11:
+		return "0"
+	case 10:
+		return "1"
+	default:
+		return strconv.Itoa(digit)
+	}
+}
+
+// portugueseCheck calculates the check digits of a Portuguese NIB.
+func portugueseCheck(bankID, account string) string {
+	return twoDigits(98 - mod97(bankID+account+"00"))
+}
+
+// italianCIN calculates the Italian CIN (control internal number).
+func italianCIN(bankID, account string) string {
+	// values of the characters at odd positions for digits 0-9 and letters A-Z
+	odd := [...]int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21, 2, 4, 18, 20, 11, 3, 6, 8, 12, 14, 16, 10, 22, 25, 24, 23}
+
+	var sum int
+	for i, r := range bankID + account {
+		var value int
+		if r >= 'A' && r <= 'Z' {
+			value = int(r - 'A')
+		} else {
+			value = int(r - '0')
+		}
+		if i%2 == 0 {
+			sum += odd[value]
+			continue
+		}
+		sum += value
+	}
+	return string(rune('A' + sum%26))
+}
+
+func twoDigits(n int64) string {
+	if n < 10 {
+		return "0" + strconv.FormatInt(n, 10)
+	}
+	return strconv.FormatInt(n, 10)
+}
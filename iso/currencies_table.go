@@ -0,0 +1,173 @@
+package iso
+
+// currencies returns the active ISO 4217 currencies with their minor units. Funds and precious metals
+// without minor units are not included.
+func currencies() []Currency {
+	return []Currency{
+		{Code: "AED", Name: "UAE Dirham", MinorUnits: 2},
+		{Code: "AFN", Name: "Afghani", MinorUnits: 2},
+		{Code: "ALL", Name: "Lek", MinorUnits: 2},
+		{Code: "AMD", Name: "Armenian Dram", MinorUnits: 2},
+		{Code: "AOA", Name: "Kwanza", MinorUnits: 2},
+		{Code: "ARS", Name: "Argentine Peso", MinorUnits: 2},
+		{Code: "AUD", Name: "Australian Dollar", MinorUnits: 2},
+		{Code: "AWG", Name: "Aruban Florin", MinorUnits: 2},
+		{Code: "AZN", Name: "Azerbaijan Manat", MinorUnits: 2},
+		{Code: "BAM", Name: "Convertible Mark", MinorUnits: 2},
+		{Code: "BBD", Name: "Barbados Dollar", MinorUnits: 2},
+		{Code: "BDT", Name: "Taka", MinorUnits: 2},
+		{Code: "BGN", Name: "Bulgarian Lev", MinorUnits: 2},
+		{Code: "BHD", Name: "Bahraini Dinar", MinorUnits: 3},
+		{Code: "BIF", Name: "Burundi Franc", MinorUnits: 0},
+		{Code: "BMD", Name: "Bermudian Dollar", MinorUnits: 2},
+		{Code: "BND", Name: "Brunei Dollar", MinorUnits: 2},
+		{Code: "BOB", Name: "Boliviano", MinorUnits: 2},
+		{Code: "BOV", Name: "Mvdol", MinorUnits: 2},
+		{Code: "BRL", Name: "Brazilian Real", MinorUnits: 2},
+		{Code: "BSD", Name: "Bahamian Dollar", MinorUnits: 2},
+		{Code: "BTN", Name: "Ngultrum", MinorUnits: 2},
+		{Code: "BWP", Name: "Pula", MinorUnits: 2},
+		{Code: "BYN", Name: "Belarusian Ruble", MinorUnits: 2},
+		{Code: "BZD", Name: "Belize Dollar", MinorUnits: 2},
+		{Code: "CAD", Name: "Canadian Dollar", MinorUnits: 2},
+		{Code: "CDF", Name: "Congolese Franc", MinorUnits: 2},
+		{Code: "CHE", Name: "WIR Euro", MinorUnits: 2},
+		{Code: "CHF", Name: "Swiss Franc", MinorUnits: 2},
+		{Code: "CHW", Name: "WIR Franc", MinorUnits: 2},
+		{Code: "CLF", Name: "Unidad de Fomento", MinorUnits: 4},
+		{Code: "CLP", Name: "Chilean Peso", MinorUnits: 0},
+		{Code: "CNY", Name: "Yuan Renminbi", MinorUnits: 2},
+		{Code: "COP", Name: "Colombian Peso", MinorUnits: 2},
+		{Code: "COU", Name: "Unidad de Valor Real", MinorUnits: 2},
+		{Code: "CRC", Name: "Costa Rican Colon", MinorUnits: 2},
+		{Code: "CUP", Name: "Cuban Peso", MinorUnits: 2},
+		{Code: "CVE", Name: "Cabo Verde Escudo", MinorUnits: 2},
+		{Code: "CZK", Name: "Czech Koruna", MinorUnits: 2},
+		{Code: "DJF", Name: "Djibouti Franc", MinorUnits: 0},
+		{Code: "DKK", Name: "Danish Krone", MinorUnits: 2},
+		{Code: "DOP", Name: "Dominican Peso", MinorUnits: 2},
+		{Code: "DZD", Name: "Algerian Dinar", MinorUnits: 2},
+		{Code: "EGP", Name: "Egyptian Pound", MinorUnits: 2},
+		{Code: "ERN", Name: "Nakfa", MinorUnits: 2},
+		{Code: "ETB", Name: "Ethiopian Birr", MinorUnits: 2},
+		{Code: "EUR", Name: "Euro", MinorUnits: 2},
+		{Code: "FJD", Name: "Fiji Dollar", MinorUnits: 2},
+		{Code: "FKP", Name: "Falkland Islands Pound", MinorUnits: 2},
+		{Code: "GBP", Name: "Pound Sterling", MinorUnits: 2},
+		{Code: "GEL", Name: "Lari", MinorUnits: 2},
+		{Code: "GHS", Name: "Ghana Cedi", MinorUnits: 2},
+		{Code: "GIP", Name: "Gibraltar Pound", MinorUnits: 2},
+		{Code: "GMD", Name: "Dalasi", MinorUnits: 2},
+		{Code: "GNF", Name: "Guinean Franc", MinorUnits: 0},
+		{Code: "GTQ", Name: "Quetzal", MinorUnits: 2},
+		{Code: "GYD", Name: "Guyana Dollar", MinorUnits: 2},
+		{Code: "HKD", Name: "Hong Kong Dollar", MinorUnits: 2},
+		{Code: "HNL", Name: "Lempira", MinorUnits: 2},
+		{Code: "HTG", Name: "Gourde", MinorUnits: 2},
+		{Code: "HUF", Name: "Forint", MinorUnits: 2},
+		{Code: "IDR", Name: "Rupiah", MinorUnits: 2},
+		{Code: "ILS", Name: "New Israeli Sheqel", MinorUnits: 2},
+		{Code: "INR", Name: "Indian Rupee", MinorUnits: 2},
+		{Code: "IQD", Name: "Iraqi Dinar", MinorUnits: 3},
+		{Code: "IRR", Name: "Iranian Rial", MinorUnits: 2},
+		{Code: "ISK", Name: "Iceland Krona", MinorUnits: 0},
+		{Code: "JMD", Name: "Jamaican Dollar", MinorUnits: 2},
+		{Code: "JOD", Name: "Jordanian Dinar", MinorUnits: 3},
+		{Code: "JPY", Name: "Yen", MinorUnits: 0},
+		{Code: "KES", Name: "Kenyan Shilling", MinorUnits: 2},
+		{Code: "KGS", Name: "Som", MinorUnits: 2},
+		{Code: "KHR", Name: "Riel", MinorUnits: 2},
+		{Code: "KMF", Name: "Comorian Franc", MinorUnits: 0},
+		{Code: "KPW", Name: "North Korean Won", MinorUnits: 2},
+		{Code: "KRW", Name: "Won", MinorUnits: 0},
+		{Code: "KWD", Name: "Kuwaiti Dinar", MinorUnits: 3},
+		{Code: "KYD", Name: "Cayman Islands Dollar", MinorUnits: 2},
+		{Code: "KZT", Name: "Tenge", MinorUnits: 2},
+		{Code: "LAK", Name: "Lao Kip", MinorUnits: 2},
+		{Code: "LBP", Name: "Lebanese Pound", MinorUnits: 2},
+		{Code: "LKR", Name: "Sri Lanka Rupee", MinorUnits: 2},
+		{Code: "LRD", Name: "Liberian Dollar", MinorUnits: 2},
+		{Code: "LSL", Name: "Loti", MinorUnits: 2},
+		{Code: "LYD", Name: "Libyan Dinar", MinorUnits: 3},
+		{Code: "MAD", Name: "Moroccan Dirham", MinorUnits: 2},
+		{Code: "MDL", Name: "Moldovan Leu", MinorUnits: 2},
+		{Code: "MGA", Name: "Malagasy Ariary", MinorUnits: 2},
+		{Code: "MKD", Name: "Denar", MinorUnits: 2},
+		{Code: "MMK", Name: "Kyat", MinorUnits: 2},
+		{Code: "MNT", Name: "Tugrik", MinorUnits: 2},
+		{Code: "MOP", Name: "Pataca", MinorUnits: 2},
+		{Code: "MRU", Name: "Ouguiya", MinorUnits: 2},
+		{Code: "MUR", Name: "Mauritius Rupee", MinorUnits: 2},
+		{Code: "MVR", Name: "Rufiyaa", MinorUnits: 2},
+		{Code: "MWK", Name: "Malawi Kwacha", MinorUnits: 2},
+		{Code: "MXN", Name: "Mexican Peso", MinorUnits: 2},
+		{Code: "MXV", Name: "Mexican Unidad de Inversion (UDI)", MinorUnits: 2},
+		{Code: "MYR", Name: "Malaysian Ringgit", MinorUnits: 2},
+		{Code: "MZN", Name: "Mozambique Metical", MinorUnits: 2},
+		{Code: "NAD", Name: "Namibia Dollar", MinorUnits: 2},
+		{Code: "NGN", Name: "Naira", MinorUnits: 2},
+		{Code: "NIO", Name: "Cordoba Oro", MinorUnits: 2},
+		{Code: "NOK", Name: "Norwegian Krone", MinorUnits: 2},
+		{Code: "NPR", Name: "Nepalese Rupee", MinorUnits: 2},
+		{Code: "NZD", Name: "New Zealand Dollar", MinorUnits: 2},
+		{Code: "OMR", Name: "Rial Omani", MinorUnits: 3},
+		{Code: "PAB", Name: "Balboa", MinorUnits: 2},
+		{Code: "PEN", Name: "Sol", MinorUnits: 2},
+		{Code: "PGK", Name: "Kina", MinorUnits: 2},
+		{Code: "PHP", Name: "Philippine Peso", MinorUnits: 2},
+		{Code: "PKR", Name: "Pakistan Rupee", MinorUnits: 2},
+		{Code: "PLN", Name: "Zloty", MinorUnits: 2},
+		{Code: "PYG", Name: "Guarani", MinorUnits: 0},
+		{Code: "QAR", Name: "Qatari Rial", MinorUnits: 2},
+		{Code: "RON", Name: "Romanian Leu", MinorUnits: 2},
+		{Code: "RSD", Name: "Serbian Dinar", MinorUnits: 2},
+		{Code: "RUB", Name: "Russian Ruble", MinorUnits: 2},
+		{Code: "RWF", Name: "Rwanda Franc", MinorUnits: 0},
+		{Code: "SAR", Name: "Saudi Riyal", MinorUnits: 2},
+		{Code: "SBD", Name: "Solomon Islands Dollar", MinorUnits: 2},
+		{Code: "SCR", Name: "Seychelles Rupee", MinorUnits: 2},
+		{Code: "SDG", Name: "Sudanese Pound", MinorUnits: 2},
+		{Code: "SEK", Name: "Swedish Krona", MinorUnits: 2},
+		{Code: "SGD", Name: "Singapore Dollar", MinorUnits: 2},
+		{Code: "SHP", Name: "Saint Helena Pound", MinorUnits: 2},
+		{Code: "SLE", Name: "Leone", MinorUnits: 2},
+		{Code: "SOS", Name: "Somali Shilling", MinorUnits: 2},
+		{Code: "SRD", Name: "Surinam Dollar", MinorUnits: 2},
+		{Code: "SSP", Name: "South Sudanese Pound", MinorUnits: 2},
+		{Code: "STN", Name: "Dobra", MinorUnits: 2},
+		{Code: "SVC", Name: "El Salvador Colon", MinorUnits: 2},
+		{Code: "SYP", Name: "Syrian Pound", MinorUnits: 2},
+		{Code: "SZL", Name: "Lilangeni", MinorUnits: 2},
+		{Code: "THB", Name: "Baht", MinorUnits: 2},
+		{Code: "TJS", Name: "Somoni", MinorUnits: 2},
+		{Code: "TMT", Name: "Turkmenistan New Manat", MinorUnits: 2},
+		{Code: "TND", Name: "Tunisian Dinar", MinorUnits: 3},
+		{Code: "TOP", Name: "Pa'anga", MinorUnits: 2},
+		{Code: "TRY", Name: "Turkish Lira", MinorUnits: 2},
+		{Code: "TTD", Name: "Trinidad and Tobago Dollar", MinorUnits: 2},
+		{Code: "TWD", Name: "New Taiwan Dollar", MinorUnits: 2},
+		{Code: "TZS", Name: "Tanzanian Shilling", MinorUnits: 2},
+		{Code: "UAH", Name: "Hryvnia", MinorUnits: 2},
+		{Code: "UGX", Name: "Uganda Shilling", MinorUnits: 0},
+		{Code: "USD", Name: "US Dollar", MinorUnits: 2},
+		{Code: "USN", Name: "US Dollar (Next day)", MinorUnits: 2},
+		{Code: "UYI", Name: "Uruguay Peso en Unidades Indexadas (UI)", MinorUnits: 0},
+		{Code: "UYU", Name: "Peso Uruguayo", MinorUnits: 2},
+		{Code: "UYW", Name: "Unidad Previsional", MinorUnits: 4},
+		{Code: "UZS", Name: "Uzbekistan Sum", MinorUnits: 2},
+		{Code: "VED", Name: "Bolívar Soberano", MinorUnits: 2},
+		{Code: "VES", Name: "Bolívar Soberano", MinorUnits: 2},
+		{Code: "VND", Name: "Dong", MinorUnits: 0},
+		{Code: "VUV", Name: "Vatu", MinorUnits: 0},
+		{Code: "WST", Name: "Tala", MinorUnits: 2},
+		{Code: "XAF", Name: "CFA Franc BEAC", MinorUnits: 0},
+		{Code: "XCD", Name: "East Caribbean Dollar", MinorUnits: 2},
+		{Code: "XCG", Name: "Caribbean Guilder", MinorUnits: 2},
+		{Code: "XOF", Name: "CFA Franc BCEAO", MinorUnits: 0},
+		{Code: "XPF", Name: "CFP Franc", MinorUnits: 0},
+		{Code: "YER", Name: "Yemeni Rial", MinorUnits: 2},
+		{Code: "ZAR", Name: "Rand", MinorUnits: 2},
+		{Code: "ZMW", Name: "Zambian Kwacha", MinorUnits: 2},
+		{Code: "ZWG", Name: "Zimbabwe Gold", MinorUnits: 2},
+	}
+}
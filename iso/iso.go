@@ -0,0 +1,97 @@
+/*
+Package iso holds the ISO 3166-1 alpha-2 country and the ISO 4217 currency tables.
+
+The tables are compiled into the module, so no data files are required at runtime:
+
+	name, ok := iso.CountryName("GB")   // "United Kingdom", true
+	units, ok := iso.MinorUnits("JPY")  // 0, true
+*/
+package iso
+
+// Country is an entry of the ISO 3166-1 table.
+type Country struct {
+	// Code is the alpha-2 code of the country.
+	Code string
+	// Name is the English short name of the country.
+	Name string
+}
+
+// Currency is an entry of the ISO 4217 table.
+type Currency struct {
+	// Code is the alphabetic code of the currency.
+	Code string
+	// Name is the English name of the currency.
+	Name string
+	// MinorUnits is the number of digits after the decimal separator (e.g. 2 for GBP, 0 for JPY).
+	MinorUnits int
+}
+
+// lookup tables by code. The tables are static, so they are built once when the package is initialised.
+var (
+	countryByCode  = indexCountries()  //nolint:gochecknoglobals // read only after initialisation
+	currencyByCode = indexCurrencies() //nolint:gochecknoglobals // read only after initialisation
+)
+
+func indexCountries() map[string]Country {
+	list := countries()
+	index := make(map[string]Country, len(list))
+	for _, c := range list {
+		index[c.Code] = c
+	}
+	return index
+}
+
+func indexCurrencies() map[string]Currency {
+	list := currencies()
+	index := make(map[string]Currency, len(list))
+	for _, c := range list {
+		index[c.Code] = c
+	}
+	return index
+}
+
+// Countries returns all countries of the ISO 3166-1 table.
+func Countries() []Country {
+	return countries()
+}
+
+// Currencies returns all currencies of the ISO 4217 table.
+func Currencies() []Currency {
+	return currencies()
+}
+
+// LookupCountry returns the country of the alpha-2 code. Reports false if the code is unknown.
+func LookupCountry(code string) (Country, bool) {
+	c, ok := countryByCode[code]
+	return c, ok
+}
+
+// IsCountry reports if the code is an ISO 3166-1 alpha-2 country code.
+func IsCountry(code string) bool {
+	_, ok := LookupCountry(code)
+	return ok
+}
+
+// CountryName returns the English short name of the country. Reports false if the code is unknown.
+func CountryName(code string) (string, bool) {
+	c, ok := LookupCountry(code)
+	return c.Name, ok
+}
+
+// LookupCurrency returns the currency of the alphabetic code. Reports false if the code is unknown.
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencyByCode[code]
+	return c, ok
+}
+
+// IsCurrency reports if the code is an ISO 4217 currency code.
+func IsCurrency(code string) bool {
+	_, ok := LookupCurrency(code)
+	return ok
+}
+
+// MinorUnits returns the number of minor units of the currency. Reports false if the code is unknown.
+func MinorUnits(code string) (int, bool) {
+	c, ok := LookupCurrency(code)
+	return c.MinorUnits, ok
+}
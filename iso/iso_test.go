@@ -0,0 +1,73 @@
+package iso
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCountryName(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   string
+		wantOk bool
+	}{
+		{code: "GB", want: "United Kingdom", wantOk: true},
+		{code: "DE", want: "Germany", wantOk: true},
+		{code: "ZZ", wantOk: false},
+		{code: "gb", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert := is.New(t)
+
+			name, ok := CountryName(tt.code)
+			assert.Equal(ok, tt.wantOk)
+			assert.Equal(name, tt.want)
+			assert.Equal(IsCountry(tt.code), tt.wantOk)
+		})
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   int
+		wantOk bool
+	}{
+		{code: "GBP", want: 2, wantOk: true},
+		{code: "JPY", want: 0, wantOk: true},
+		{code: "KWD", want: 3, wantOk: true},
+		{code: "CLF", want: 4, wantOk: true},
+		{code: "XXQ", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert := is.New(t)
+
+			units, ok := MinorUnits(tt.code)
+			assert.Equal(ok, tt.wantOk)
+			assert.Equal(units, tt.want)
+			assert.Equal(IsCurrency(tt.code), tt.wantOk)
+		})
+	}
+}
+
+func TestTables(t *testing.T) {
+	assert := is.New(t)
+
+	seen := map[string]bool{}
+	for _, c := range Countries() {
+		assert.True(len(c.Code) == 2 && c.Name != "")
+		assert.True(!seen[c.Code])
+		seen[c.Code] = true
+	}
+	assert.Equal(len(seen), 249)
+
+	seen = map[string]bool{}
+	for _, c := range Currencies() {
+		assert.True(len(c.Code) == 3 && c.Name != "")
+		assert.True(!seen[c.Code])
+		seen[c.Code] = true
+	}
+}
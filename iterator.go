@@ -0,0 +1,96 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrLimitExceeded is returned if a list call would return more records than the given limit.
+var ErrLimitExceeded = errors.New("number of records exceeds the limit")
+
+// pageIterator walks through the pages of a list endpoint following the `next` links of the server.
+// Pages are fetched on demand. It is the type independent part of the typed iterators like AccountIterator.
+type pageIterator struct {
+	cl       *Client
+	ctx      context.Context
+	attrType attrType
+	factory  func() responseFiller
+
+	nextURL string
+	page    []responseFiller
+	current responseFiller
+	err     error
+}
+
+func newPageIterator(ctx context.Context, cl *Client, uri string, typ attrType,
+	factory func() responseFiller) *pageIterator {
+	return &pageIterator{
+		cl:       cl,
+		ctx:      ctx,
+		attrType: typ,
+		factory:  factory,
+		nextURL:  uri,
+	}
+}
+
+// next advances to the next record. It fetches the next page if the current one is exhausted.
+func (s *pageIterator) next() bool {
+	for len(s.page) == 0 {
+		if s.err != nil || s.nextURL == "" {
+			s.current = nil
+			return false
+		}
+		s.fetchPage()
+	}
+
+	s.current = s.page[0]
+	s.page = s.page[1:]
+	return true
+}
+
+func (s *pageIterator) fetchPage() {
+	uri := s.nextURL
+	links := map[string]string{}
+	err := s.cl.request(s.ctx, uri, s.attrType, withLinks(links),
+		withListResp(s.factory, func(data responseFiller) {
+			s.page = append(s.page, data)
+		}),
+	)
+	if err != nil {
+		s.err = err
+		return
+	}
+
+	s.nextURL = ""
+	// an empty page or a next link pointing to the page just fetched means there is nothing more to fetch.
+	if len(s.page) == 0 {
+		return
+	}
+	next, ok := links["next"]
+	if !ok || next == "" {
+		return
+	}
+	nextURL, err := s.cl.resolveLink(next)
+	if err != nil {
+		s.err = err
+		return
+	}
+	if nextURL != uri {
+		s.nextURL = nextURL
+	}
+}
+
+// resolveLink turns a link returned by the server into an url. Relative links are resolved
+// against the base url of the client.
+func (s *Client) resolveLink(link string) (string, error) {
+	uri, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("invalid link %q in response: %w", link, err)
+	}
+	if uri.IsAbs() {
+		return link, nil
+	}
+	return s.baseURL + uri.RequestURI(), nil
+}
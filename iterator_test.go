@@ -0,0 +1,95 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// pagedServer serves `total` accounts in pages of `size` with links like the account API does.
+func pagedServer(total, size int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+
+		var data string
+		for i := page * size; i < (page+1)*size && i < total; i++ {
+			if data != "" {
+				data += ","
+			}
+			data += fmt.Sprintf(`{"type":"accounts","id":"id-%d","attributes":{"country":"GB"}}`, i)
+		}
+
+		links := fmt.Sprintf(`"self":"%s?page%%5Bnumber%%5D=%d"`, accountsPath, page)
+		if (page+1)*size < total {
+			links += fmt.Sprintf(`,"next":"%s?page%%5Bnumber%%5D=%d"`, accountsPath, page+1)
+		}
+		_, _ = fmt.Fprintf(w, `{"data":[%s],"links":{%s}}`, data, links)
+	}))
+}
+
+func TestClient_IterAccounts(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		size  int
+	}{
+		{name: "no accounts", total: 0, size: 2},
+		{name: "single page", total: 2, size: 5},
+		{name: "full last page", total: 4, size: 2},
+		{name: "partial last page", total: 5, size: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			srv := pagedServer(tt.total, tt.size)
+			defer srv.Close()
+
+			cl := NewClient(srv.URL)
+			it := cl.IterAccounts(context.Background())
+
+			var count int
+			for it.Next() {
+				assert.Equal(it.Account().ID(), "id-"+strconv.Itoa(count))
+				count++
+			}
+			assert.NoErr(it.Err())
+			assert.Equal(count, tt.total)
+			assert.True(it.Account() == nil)
+		})
+	}
+}
+
+func TestClient_IterAccountsError(t *testing.T) {
+	assert := is.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	it := NewClient(srv.URL).IterAccounts(context.Background())
+	assert.True(!it.Next())
+	assert.True(errors.Is(it.Err(), ErrForbidden))
+}
+
+func TestClient_ListAllAccounts(t *testing.T) {
+	assert := is.New(t)
+
+	srv := pagedServer(5, 2)
+	defer srv.Close()
+
+	cl := NewClient(srv.URL)
+	accounts, err := cl.ListAllAccounts(context.Background(), 5)
+	assert.NoErr(err)
+	assert.Equal(len(accounts), 5)
+
+	_, err = cl.ListAllAccounts(context.Background(), 4)
+	assert.True(errors.Is(err, ErrLimitExceeded))
+}
@@ -35,6 +35,42 @@ func WithPageSize(size int) ListOption {
 	}
 }
 
+// WithFilterBankID can be used with a list call to only return records with the given bank id.
+func WithFilterBankID(bankID string) ListOption {
+	return withFilter("bank_id", bankID)
+}
+
+// WithFilterBankIDCode can be used with a list call to only return records with the given bank id code.
+func WithFilterBankIDCode(bankIDCode string) ListOption {
+	return withFilter("bank_id_code", bankIDCode)
+}
+
+// WithFilterAccountNumber can be used with a list call to only return records with the given account number.
+func WithFilterAccountNumber(accountNumber string) ListOption {
+	return withFilter("account_number", accountNumber)
+}
+
+// WithFilterIBAN can be used with a list call to only return records with the given IBAN.
+func WithFilterIBAN(iban string) ListOption {
+	return withFilter("iban", iban)
+}
+
+// WithFilterCountry can be used with a list call to only return records of the given country.
+func WithFilterCountry(country string) ListOption {
+	return withFilter("country", country)
+}
+
+// WithFilterCustomerID can be used with a list call to only return records with the given customer id.
+func WithFilterCustomerID(customerID string) ListOption {
+	return withFilter("customer_id", customerID)
+}
+
+func withFilter(attribute, value string) ListOption {
+	return func(params url.Values) {
+		params.Set("filter["+attribute+"]", value)
+	}
+}
+
 func applyOptions(params url.Values, options []ListOption) {
 	for _, option := range options {
 		option(params)
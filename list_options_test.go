@@ -0,0 +1,53 @@
+package form3
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func Test_applyOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []ListOption
+		want    string
+	}{
+		{
+			name: "no options",
+			want: "",
+		},
+		{
+			name:    "pagination",
+			options: []ListOption{WithPageNo(2), WithPageSize(50)},
+			want:    "page%5Bnumber%5D=2&page%5Bsize%5D=50",
+		},
+		{
+			name: "multiple filters",
+			options: []ListOption{
+				WithFilterCountry("GB"),
+				WithFilterBankID("400300"),
+				WithFilterBankIDCode("GBDSC"),
+				WithFilterAccountNumber("41426819"),
+				WithFilterIBAN("GB11NWBK40030041426819"),
+				WithFilterCustomerID("cust-1"),
+			},
+			want: "filter%5Baccount_number%5D=41426819&filter%5Bbank_id%5D=400300&filter%5Bbank_id_code%5D=GBDSC" +
+				"&filter%5Bcountry%5D=GB&filter%5Bcustomer_id%5D=cust-1&filter%5Biban%5D=GB11NWBK40030041426819",
+		},
+		{
+			name:    "last filter on same attribute wins",
+			options: []ListOption{WithFilterCountry("GB"), WithFilterCountry("DE")},
+			want:    "filter%5Bcountry%5D=DE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			params := url.Values{}
+			applyOptions(params, tt.options)
+			assert.Equal(params.Encode(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package form3
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tehsphinx/dbg"
+)
+
+// LogLevel defines the severity of a LogEvent.
+type LogLevel int
+
+// log levels
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the name of the log level.
+func (s LogLevel) String() string {
+	switch s {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+	return fmt.Sprintf("level(%d)", int(s))
+}
+
+// LogEvent holds the structured information about one attempt of a request to the API.
+type LogEvent struct {
+	Level   LogLevel
+	Message string
+	// Method and URL of the request.
+	Method string
+	URL    string
+	// Status is the http status code of the response. 0 if no response was received.
+	Status int
+	// Duration of the attempt.
+	Duration time.Duration
+	// Attempt is the number of the attempt starting with 1. Is higher than 1 for retried requests.
+	Attempt int
+	// RequestID is the request id the server assigned to the request (if provided).
+	RequestID string
+	// RequestSize and ResponseSize are the sizes of the request and response bodies in bytes.
+	RequestSize  int
+	ResponseSize int
+	// RequestBody and ResponseBody are only set if body logging is enabled (see WithBodyLogging).
+	// Sensitive values are redacted (see WithRedaction).
+	RequestBody  string
+	ResponseBody string
+	// Err is the error the attempt failed with.
+	Err error
+}
+
+// Logger receives the log events of the client. See WithLogger.
+type Logger interface {
+	Log(event LogEvent)
+}
+
+// LoggerFunc is an adapter to allow the use of ordinary functions as Logger.
+type LoggerFunc func(event LogEvent)
+
+// Log implements the Logger interface.
+func (s LoggerFunc) Log(event LogEvent) {
+	s(event)
+}
+
+// NewStdLogger creates a Logger writing the events as key=value pairs to the given standard library logger.
+func NewStdLogger(logger *log.Logger) Logger {
+	return LoggerFunc(func(event LogEvent) {
+		fields := []string{
+			"level=" + event.Level.String(),
+			fmt.Sprintf("msg=%q", event.Message),
+			"method=" + event.Method,
+			fmt.Sprintf("url=%q", event.URL),
+			fmt.Sprintf("status=%d", event.Status),
+			"duration=" + event.Duration.String(),
+			fmt.Sprintf("attempt=%d", event.Attempt),
+		}
+		if event.RequestID != "" {
+			fields = append(fields, "request_id="+event.RequestID)
+		}
+		fields = append(fields,
+			fmt.Sprintf("request_size=%d", event.RequestSize),
+			fmt.Sprintf("response_size=%d", event.ResponseSize))
+		if event.RequestBody != "" {
+			fields = append(fields, fmt.Sprintf("request_body=%q", event.RequestBody))
+		}
+		if event.ResponseBody != "" {
+			fields = append(fields, fmt.Sprintf("response_body=%q", event.ResponseBody))
+		}
+		if event.Err != nil {
+			fields = append(fields, fmt.Sprintf("error=%q", event.Err))
+		}
+		logger.Println(strings.Join(fields, " "))
+	})
+}
+
+// dbgLogger prints the communication in color. Used by WithDebug.
+func dbgLogger(event LogEvent) {
+	if event.Status == 0 && event.Err == nil {
+		// not an attempt, but e.g. the notice about a retry
+		dbg.Yellow(event.Message, event.Method, event.URL)
+		return
+	}
+
+	dbg.Green(event.Method, event.URL, event.Status, event.Duration)
+	if event.RequestBody != "" {
+		dbg.Blue(event.RequestBody)
+	}
+
+	switch {
+	case event.Err != nil:
+		dbg.Red(event.Message+":", event.Err)
+	case event.Level >= LevelWarn:
+		dbg.Yellow(event.Message)
+		if event.ResponseBody != "" {
+			dbg.Red(event.ResponseBody)
+		}
+	case event.ResponseBody != "":
+		dbg.Cyan(event.ResponseBody)
+	}
+}
+
+// logAttempt logs one attempt of a request. The response is nil if the attempt failed.
+func (s *Client) logAttempt(event LogEvent, body []byte, resp *http.Response, respBody []byte) {
+	if s.logger == nil {
+		return
+	}
+
+	event.Level = LevelInfo
+	event.Message = "request completed"
+	event.RequestSize = len(body)
+	if resp != nil {
+		event.Status = resp.StatusCode
+		event.RequestID = resp.Header.Get(requestIDHeader)
+		event.ResponseSize = len(respBody)
+	}
+
+	switch {
+	case event.Err != nil:
+		event.Level = LevelError
+		event.Message = "request failed"
+	case event.Status >= http.StatusBadRequest:
+		event.Level = LevelWarn
+		event.Message = "request rejected"
+	}
+	if event.Level < s.logLevel {
+		return
+	}
+
+	event.URL = s.redaction.redactURL(event.URL)
+	if s.logBodies {
+		event.RequestBody = s.redaction.redactBody(body)
+		event.ResponseBody = s.redaction.redactBody(respBody)
+	}
+	s.logger.Log(event)
+}
+
+// logRetry logs that a failed request is going to be retried.
+func (s *Client) logRetry(method, url string, attempt int, wait time.Duration) {
+	if s.logger == nil || LevelInfo < s.logLevel {
+		return
+	}
+
+	s.logger.Log(LogEvent{
+		Level:   LevelInfo,
+		Message: fmt.Sprintf("retrying request in %s", wait),
+		Method:  method,
+		URL:     s.redaction.redactURL(url),
+		Attempt: attempt + 1,
+	})
+}
@@ -0,0 +1,145 @@
+package form3
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestClient_WithLogger(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(requestIDHeader, "req-1")
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(accountResponse))
+	}))
+	defer srv.Close()
+
+	retry := WithRetryPolicy(RetryPolicy{MaxAttempts: 2, RetryableStatus: []int{http.StatusServiceUnavailable}})
+
+	tests := []struct {
+		name        string
+		options     []ClientOption
+		wantLevels  []LogLevel
+		wantBodies  bool
+		wantAttempt []int
+	}{
+		{
+			name:        "info level",
+			options:     []ClientOption{WithLogger(nil, LevelInfo)},
+			wantLevels:  []LogLevel{LevelWarn, LevelInfo, LevelInfo},
+			wantAttempt: []int{1, 2, 2},
+		},
+		{
+			name:        "warn level",
+			options:     []ClientOption{WithLogger(nil, LevelWarn)},
+			wantLevels:  []LogLevel{LevelWarn},
+			wantAttempt: []int{1},
+		},
+		{
+			name:        "with bodies",
+			options:     []ClientOption{WithLogger(nil, LevelWarn), WithBodyLogging()},
+			wantLevels:  []LogLevel{LevelWarn},
+			wantBodies:  true,
+			wantAttempt: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+			atomic.StoreInt32(&requests, 0)
+
+			var events []LogEvent
+			cl := NewClient(srv.URL, append(tt.options, retry)...)
+			cl.logger = LoggerFunc(func(event LogEvent) {
+				events = append(events, event)
+			})
+
+			_, err := cl.FetchAccount(context.Background(), "some-id")
+			assert.NoErr(err)
+
+			assert.Equal(len(events), len(tt.wantLevels))
+			for i, event := range events {
+				assert.Equal(event.Level, tt.wantLevels[i])
+				assert.Equal(event.Attempt, tt.wantAttempt[i])
+				assert.Equal(event.Method, http.MethodGet)
+				assert.Equal(event.ResponseBody != "", tt.wantBodies && event.ResponseSize != 0)
+			}
+			assert.Equal(events[0].Status, http.StatusServiceUnavailable)
+			assert.Equal(events[0].RequestID, "req-1")
+		})
+	}
+}
+
+func TestNewStdLogger(t *testing.T) {
+	assert := is.New(t)
+
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0))
+	logger.Log(LogEvent{
+		Level:        LevelWarn,
+		Message:      "request rejected",
+		Method:       http.MethodGet,
+		URL:          "http://localhost/v1/organisation/accounts",
+		Status:       http.StatusNotFound,
+		Duration:     time.Millisecond,
+		Attempt:      1,
+		RequestID:    "req-1",
+		ResponseSize: 2,
+	})
+
+	want := `level=warn msg="request rejected" method=GET url="http://localhost/v1/organisation/accounts" ` +
+		`status=404 duration=1ms attempt=1 request_id=req-1 request_size=0 response_size=2`
+	assert.Equal(strings.TrimSpace(buf.String()), want)
+}
+
+func TestClient_logRedactsFailedFilteredList(t *testing.T) {
+	const accountNumber, iban = "41426819", "GB16NWBK40030041426819"
+	filters := []ListOption{WithFilterAccountNumber(accountNumber), WithFilterIBAN(iban)}
+
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer rejecting.Close()
+	// a closed server makes the request fail with a connection error
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		level   LogLevel
+	}{
+		{name: "connection error", baseURL: closed.URL, level: LevelError},
+		{name: "api error", baseURL: rejecting.URL, level: LevelWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			var buf bytes.Buffer
+			cl := NewClient(tt.baseURL, WithLogger(NewStdLogger(log.New(&buf, "", 0)), LevelDebug))
+
+			_, err := cl.ListAccounts(context.Background(), filters...)
+			assert.True(err != nil)
+			assert.True(!strings.Contains(err.Error(), accountNumber))
+			assert.True(!strings.Contains(err.Error(), iban))
+
+			logged := buf.String()
+			assert.True(strings.Contains(logged, "level="+tt.level.String()))
+			assert.True(!strings.Contains(logged, accountNumber))
+			assert.True(!strings.Contains(logged, iban))
+		})
+	}
+}
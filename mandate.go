@@ -0,0 +1,134 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const mandatesPath = "/v1/transaction/mandates"
+
+// client side mandate validation errors
+var (
+	ErrInvalidScheme           = errors.New("paymentScheme should be a direct debit scheme like BACS or SEPADD")
+	ErrInvalidMandateReference = errors.New("reference is required")
+	ErrInvalidSignatureDate    = errors.New("signatureDate should be a date formatted as YYYY-MM-DD")
+)
+
+// Mandate authorises the beneficiary to collect direct debits from the account of the debtor.
+type Mandate struct {
+	baseAttr
+
+	PaymentScheme DirectDebitScheme `json:"payment_scheme"`
+	Status        MandateStatus     `json:"status,omitempty"`
+	StatusReason  string            `json:"status_reason,omitempty"`
+	// Reference identifies the mandate: the instruction reference for Bacs, the mandate id for SEPA.
+	Reference        string        `json:"reference"`
+	DebtorParty      *PaymentParty `json:"debtor_party,omitempty"`
+	BeneficiaryParty *PaymentParty `json:"beneficiary_party,omitempty"`
+	// SignatureDate is the date the debtor signed the mandate formatted as YYYY-MM-DD. Required for SEPA.
+	SignatureDate string `json:"signature_date,omitempty"`
+	// CreditorID is the SEPA creditor identifier of the beneficiary.
+	CreditorID string `json:"creditor_id,omitempty"`
+}
+
+// mandatePatch holds the attributes changed by CancelMandate.
+type mandatePatch struct {
+	Status       MandateStatus `json:"status"`
+	StatusReason string        `json:"status_reason,omitempty"`
+}
+
+// CreateMandate creates a new mandate. Submit it with SubmitMandate to set it up with the scheme.
+// Like CreatePayment a mandate with an id given by WithID or WithDeterministicID can be safely created again.
+func (s *Client) CreateMandate(ctx context.Context, orgID string, data *Mandate,
+	options ...CreateOption) (*Mandate, error) {
+	if err := s.validateMandate(data); err != nil {
+		return nil, fmt.Errorf("invalid Mandate information provided: %w", err)
+	}
+
+	resp := &Mandate{}
+	if err := s.create(ctx, mandatesPath, typeMandates, orgID, data, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FetchMandate retrieves the mandate with given mandate id.
+func (s *Client) FetchMandate(ctx context.Context, uid string) (*Mandate, error) {
+	resp := &Mandate{}
+	uri := s.buildURL(mandatesPath, uid, nil)
+	if err := s.request(ctx, uri, typeMandates, withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListMandates retrieves a list of mandates. It has pagination (e.g. WithPageSize).
+func (s *Client) ListMandates(ctx context.Context, opts ...ListOption) ([]Mandate, error) {
+	params := url.Values{}
+	applyOptions(params, opts)
+
+	var mandates []Mandate
+	uri := s.buildURL(mandatesPath, "", params)
+	if err := s.request(ctx, uri, typeMandates,
+		withListResp(
+			func() responseFiller {
+				return &Mandate{}
+			},
+			func(data responseFiller) {
+				mandate := data.(*Mandate)
+				mandates = append(mandates, *mandate)
+			},
+		),
+	); err != nil {
+		return nil, err
+	}
+
+	return mandates, nil
+}
+
+// CancelMandate cancels the mandate with given mandate id, so no further direct debits can be collected.
+// The version must match the current version of the mandate, otherwise a ErrConflict is returned.
+func (s *Client) CancelMandate(ctx context.Context, uid string, version int, reason string) (*Mandate, error) {
+	patch := &mandatePatch{Status: MandateStatusCancelled, StatusReason: reason}
+
+	resp := &Mandate{}
+	uri := s.buildURL(mandatesPath, uid, nil)
+	if err := s.request(ctx, uri, typeMandates, withMethod(http.MethodPatch), withUID(uid),
+		withVersion(version), withReq(patch), withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SubmitMandate submits the mandate to the scheme.
+func (s *Client) SubmitMandate(ctx context.Context, orgID, mandateID string,
+	options ...CreateOption) (*PaymentSubmission, error) {
+	return s.submit(ctx, orgID, resourcePath(mandatesPath, mandateID, submissionsPath),
+		typeMandateSubmissions, options)
+}
+
+// getValidateMandate returns the validate function for mandates. Payment schemes unknown to the client
+// are only rejected with strict enums (see WithStrictEnums).
+func getValidateMandate(strictEnums bool) func(attr *Mandate) error {
+	return func(attr *Mandate) error {
+		var errs ValidationErrors
+		if attr.PaymentScheme == "" || strictEnums && !attr.PaymentScheme.IsValid() {
+			errs.add(attrPath("PaymentScheme", "payment_scheme"), attr.PaymentScheme.String(), ErrInvalidScheme)
+		}
+		if attr.Reference == "" {
+			errs.add(attrPath("Reference", "reference"), attr.Reference, ErrInvalidMandateReference)
+		}
+		if attr.SignatureDate != "" || attr.PaymentScheme == DirectDebitSchemeSEPA {
+			if !validDate(attr.SignatureDate) {
+				errs.add(attrPath("SignatureDate", "signature_date"), attr.SignatureDate, ErrInvalidSignatureDate)
+			}
+		}
+		return errs.err()
+	}
+}
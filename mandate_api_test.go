@@ -0,0 +1,80 @@
+package form3_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/tehsphinx/form3"
+	"github.com/tehsphinx/form3/form3test"
+)
+
+// TestClient_mandates runs the mandate and direct debit lifecycle against the fake server.
+func TestClient_mandates(t *testing.T) {
+	assert := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	srv := form3test.NewServer()
+	defer srv.Close()
+	cl := form3.NewClient(srv.URL, form3.WithStrictEnums())
+
+	data := &form3.Mandate{
+		PaymentScheme:    form3.DirectDebitSchemeBacs,
+		Reference:        "DDI-4711",
+		DebtorParty:      &form3.PaymentParty{AccountName: "W Owens", AccountNumber: "31926819", BankID: "403000"},
+		BeneficiaryParty: &form3.PaymentParty{AccountName: "Utility Co", AccountNumber: "41426819", BankID: "400300"},
+	}
+	mandate, err := cl.CreateMandate(ctx, orgID, data)
+	assert.NoErr(err)
+	assert.Equal(mandate.Status, form3.MandateStatusPending)
+	assert.Equal(mandate.DebtorParty, data.DebtorParty)
+
+	submission, err := cl.SubmitMandate(ctx, orgID, mandate.ID())
+	assert.NoErr(err)
+	assert.Equal(submission.Type(), "mandate_submissions")
+
+	assert.NoErr(srv.SetMandateStatus(mandate.ID(), "active"))
+	mandate, err = cl.FetchMandate(ctx, mandate.ID())
+	assert.NoErr(err)
+	assert.Equal(mandate.Status, form3.MandateStatusActive)
+
+	mandates, err := cl.ListMandates(ctx)
+	assert.NoErr(err)
+	assert.Equal(len(mandates), 1)
+
+	// direct debits collected under the mandate
+	ddID, err := srv.AddDirectDebit(orgID, map[string]interface{}{
+		"amount": "42.00", "currency": "GBP", "payment_scheme": "BACS", "mandate_reference": "DDI-4711",
+	})
+	assert.NoErr(err)
+	directDebit, err := cl.FetchDirectDebit(ctx, ddID)
+	assert.NoErr(err)
+	assert.Equal(directDebit.Amount, "42.00")
+	assert.Equal(directDebit.PaymentScheme, form3.DirectDebitSchemeBacs)
+	assert.Equal(directDebit.MandateReference, mandate.Reference)
+
+	directDebits, err := cl.ListDirectDebits(ctx, form3.WithPageSize(10))
+	assert.NoErr(err)
+	assert.Equal(len(directDebits), 1)
+
+	ret, err := cl.ReturnDirectDebit(ctx, orgID, ddID, &form3.Return{ReturnCode: form3.ReturnCodeNoMandate})
+	assert.NoErr(err)
+	assert.Equal(ret.Type(), "directdebit_returns")
+	reversal, err := cl.ReverseDirectDebit(ctx, orgID, ddID, &form3.Reversal{Reason: form3.ReversalReasonDuplication})
+	assert.NoErr(err)
+	assert.Equal(reversal.Type(), "directdebit_reversals")
+
+	// cancel with an outdated version fails
+	_, err = cl.CancelMandate(ctx, mandate.ID(), mandate.Version()-1, "customer request")
+	assert.True(errors.Is(err, form3.ErrConflict))
+	mandate, err = cl.CancelMandate(ctx, mandate.ID(), mandate.Version(), "customer request")
+	assert.NoErr(err)
+	assert.Equal(mandate.Status, form3.MandateStatusCancelled)
+	assert.Equal(mandate.StatusReason, "customer request")
+
+	_, err = cl.CreateMandate(ctx, orgID, &form3.Mandate{PaymentScheme: form3.DirectDebitSchemeSEPA, Reference: "M-1"})
+	assert.True(errors.Is(err, form3.ErrInvalidSignatureDate))
+}
@@ -0,0 +1,64 @@
+package form3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func Test_getValidateMandate(t *testing.T) {
+	tests := []struct {
+		name    string
+		strict  bool
+		mandate *Mandate
+		wantErr error
+	}{
+		{name: "bacs", mandate: &Mandate{PaymentScheme: DirectDebitSchemeBacs, Reference: "DDI-4711"}},
+		{name: "sepa", mandate: &Mandate{PaymentScheme: DirectDebitSchemeSEPA, Reference: "M-4711",
+			SignatureDate: "2026-10-01"}},
+		{name: "unknown scheme", mandate: &Mandate{PaymentScheme: "AUBECS", Reference: "DDI-4711"}},
+		{name: "unknown scheme with strict enums", strict: true,
+			mandate: &Mandate{PaymentScheme: "AUBECS", Reference: "DDI-4711"}, wantErr: ErrInvalidScheme},
+		{name: "missing scheme", mandate: &Mandate{Reference: "DDI-4711"}, wantErr: ErrInvalidScheme},
+		{name: "missing reference", mandate: &Mandate{PaymentScheme: DirectDebitSchemeBacs},
+			wantErr: ErrInvalidMandateReference},
+		{name: "sepa without signature date", mandate: &Mandate{PaymentScheme: DirectDebitSchemeSEPA, Reference: "M-4711"},
+			wantErr: ErrInvalidSignatureDate},
+		{name: "invalid signature date", mandate: &Mandate{PaymentScheme: DirectDebitSchemeBacs, Reference: "DDI-4711",
+			SignatureDate: "01.10.2026"}, wantErr: ErrInvalidSignatureDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			err := getValidateMandate(tt.strict)(tt.mandate)
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+				return
+			}
+			assert.True(errors.Is(err, tt.wantErr))
+		})
+	}
+}
+
+func TestDirectDebitEnums_IsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{ IsValid() bool }
+		want  bool
+	}{
+		{name: "bacs", value: DirectDebitSchemeBacs, want: true},
+		{name: "lower case scheme", value: DirectDebitScheme("bacs"), want: false},
+		{name: "active", value: MandateStatusActive, want: true},
+		{name: "unknown mandate status", value: MandateStatus("suspended"), want: false},
+		{name: "first", value: SequenceTypeFirst, want: true},
+		{name: "unknown sequence type", value: SequenceType("LAST"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+			assert.Equal(tt.value.IsValid(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,384 @@
+/*
+Package modulus implements the Vocalink modulus checking of UK sort codes and account numbers.
+
+The check tells if a sort code and account number pair is plausible. It does not tell if the account
+exists. The rules are read from the weight table (valacdos.txt) and optionally the sort code substitution
+table (scsubtab.txt) published by Vocalink, so the check works offline:
+
+	checker, err := modulus.LoadChecker("valacdos.txt", "scsubtab.txt")
+	if err != nil {
+		return err
+	}
+	err = checker.Check("089999", "66374958")
+
+Sort codes not listed in the weight table cannot be checked and are considered valid.
+*/
+package modulus
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	sortCodeLength = 6
+	accountLength  = 8
+	weightCount    = sortCodeLength + accountLength
+)
+
+// errors returned by the checker
+var (
+	// ErrInvalidFormat is returned if the sort code is not 6 or the account number not 8 digits.
+	ErrInvalidFormat = errors.New("sort code must be 6 and account number 8 digits")
+	// ErrCheckFailed is returned if the sort code and account number fail the modulus check.
+	ErrCheckFailed = errors.New("modulus check failed")
+	// ErrInvalidTable is returned if a weight or substitution table cannot be parsed.
+	ErrInvalidTable = errors.New("invalid table")
+)
+
+// Method is the algorithm of a modulus check.
+type Method string
+
+// modulus check methods
+const (
+	MethodMod10 Method = "MOD10"
+	MethodMod11 Method = "MOD11"
+	MethodDblAl Method = "DBLAL"
+)
+
+// exceptions of the weight table with special treatment
+const (
+	exceptionDblAl27         = 1
+	exceptionAlternateWeight = 2
+	exceptionSkipOnCDigit    = 3
+	exceptionRemainderIsGH   = 4
+	exceptionSubstitute      = 5
+	exceptionForeignCurrency = 6
+	exceptionZeroiseOnG9     = 7
+	exceptionSortCode090126  = 8
+	exceptionSortCode309634  = 9
+	exceptionZeroiseOnAB     = 10
+	exceptionEitherOf10      = 11
+	exceptionEitherOf13      = 12
+	exceptionEitherOf12      = 13
+	exceptionShiftAccount    = 14
+)
+
+// positions of the digits in the combined sort code and account number (u v w x y z a b c d e f g h)
+const (
+	posA = 6
+	posB = 7
+	posC = 8
+	posG = 12
+	posH = 13
+)
+
+// rule is one row of the weight table.
+type rule struct {
+	start, end int
+	method     Method
+	weights    [weightCount]int
+	exception  int
+}
+
+// Checker checks sort codes and account numbers. Create one with NewChecker or LoadChecker.
+type Checker struct {
+	rules         []rule
+	substitutions map[string]string
+}
+
+// LoadChecker creates a checker from the weight table file and the optional (may be empty) sort code
+// substitution table file.
+func LoadChecker(weightsFile, substitutionsFile string) (*Checker, error) {
+	weights, err := os.Open(weightsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer weights.Close()
+
+	var substitutions io.Reader
+	if substitutionsFile != "" {
+		f, err := os.Open(substitutionsFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		substitutions = f
+	}
+	return NewChecker(weights, substitutions)
+}
+
+// NewChecker creates a checker reading the weight table and the optional (may be nil) sort code
+// substitution table from the given readers.
+func NewChecker(weights, substitutions io.Reader) (*Checker, error) {
+	rules, err := parseWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	subs := map[string]string{}
+	if substitutions != nil {
+		subs, err = parseSubstitutions(substitutions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Checker{rules: rules, substitutions: subs}, nil
+}
+
+// parseWeights parses the weight table. Each line holds: sort code range, method, 14 weights and an
+// optional exception.
+func parseWeights(r io.Reader) ([]rule, error) {
+	var rules []rule
+	err := scanLines(r, func(line int, fields []string) error {
+		if len(fields) != 3+weightCount && len(fields) != 4+weightCount {
+			return fmt.Errorf("%w: line %d: unexpected number of fields", ErrInvalidTable, line)
+		}
+
+		var (
+			ru  = rule{method: Method(fields[2])}
+			err error
+		)
+		if ru.start, err = strconv.Atoi(fields[0]); err != nil {
+			return fmt.Errorf("%w: line %d: %v", ErrInvalidTable, line, err)
+		}
+		if ru.end, err = strconv.Atoi(fields[1]); err != nil {
+			return fmt.Errorf("%w: line %d: %v", ErrInvalidTable, line, err)
+		}
+		switch ru.method {
+		case MethodMod10, MethodMod11, MethodDblAl:
+		default:
+			return fmt.Errorf("%w: line %d: unknown method %s", ErrInvalidTable, line, ru.method)
+		}
+		for i := range ru.weights {
+			if ru.weights[i], err = strconv.Atoi(fields[3+i]); err != nil {
+				return fmt.Errorf("%w: line %d: %v", ErrInvalidTable, line, err)
+			}
+		}
+		if len(fields) > 3+weightCount {
+			if ru.exception, err = strconv.Atoi(fields[3+weightCount]); err != nil {
+				return fmt.Errorf("%w: line %d: %v", ErrInvalidTable, line, err)
+			}
+		}
+
+		rules = append(rules, ru)
+		return nil
+	})
+	return rules, err
+}
+
+// parseSubstitutions parses the sort code substitution table. Each line holds the sort code and its substitute.
+func parseSubstitutions(r io.Reader) (map[string]string, error) {
+	subs := map[string]string{}
+	err := scanLines(r, func(line int, fields []string) error {
+		if len(fields) != 2 || !isDigits(fields[0], sortCodeLength) || !isDigits(fields[1], sortCodeLength) {
+			return fmt.Errorf("%w: line %d: expected two sort codes", ErrInvalidTable, line)
+		}
+		subs[fields[0]] = fields[1]
+		return nil
+	})
+	return subs, err
+}
+
+func scanLines(r io.Reader, fn func(line int, fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if err := fn(line, fields); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Check checks the sort code and account number. Returns ErrCheckFailed if they fail the modulus check.
+// Sort codes not covered by the weight table are considered valid.
+func (s *Checker) Check(sortCode, accountNumber string) error {
+	if !isDigits(sortCode, sortCodeLength) || !isDigits(accountNumber, accountLength) {
+		return ErrInvalidFormat
+	}
+
+	if !s.valid(sortCode, accountNumber) {
+		return fmt.Errorf("%w: sort code %s, account number %s", ErrCheckFailed, sortCode, accountNumber)
+	}
+	return nil
+}
+
+func (s *Checker) valid(sortCode, accountNumber string) bool {
+	rules := s.rulesFor(sortCode)
+	if len(rules) == 0 {
+		return true
+	}
+
+	first := rules[0]
+	digits := toDigits(sortCode + accountNumber)
+	if first.exception == exceptionForeignCurrency && digits[posA] >= 4 && digits[posA] <= 8 &&
+		digits[posG] == digits[posH] {
+		// foreign currency accounts cannot be checked
+		return true
+	}
+
+	firstValid := s.check(first, sortCode, accountNumber)
+	if !firstValid && first.exception == exceptionShiftAccount {
+		firstValid = s.checkShifted(first, sortCode, accountNumber)
+	}
+	if len(rules) == 1 {
+		return firstValid
+	}
+
+	second := rules[1]
+	switch {
+	case first.exception == exceptionAlternateWeight && second.exception == exceptionSortCode309634:
+		return firstValid || s.check(second, "309634", accountNumber)
+	case isEitherPair(first.exception, second.exception):
+		return firstValid || s.check(second, sortCode, accountNumber)
+	case !firstValid:
+		return false
+	case second.exception == exceptionSkipOnCDigit && (digits[posC] == 6 || digits[posC] == 9):
+		return true
+	}
+	return s.check(second, sortCode, accountNumber)
+}
+
+// isEitherPair reports if the exceptions define that the account is valid if either check passes.
+func isEitherPair(first, second int) bool {
+	return (first == exceptionEitherOf10 && second == exceptionZeroiseOnAB) ||
+		(first == exceptionZeroiseOnAB && second == exceptionEitherOf10) ||
+		(first == exceptionEitherOf13 && second == exceptionEitherOf12) ||
+		(first == exceptionEitherOf12 && second == exceptionEitherOf13)
+}
+
+// rulesFor returns the rules of the weight table covering the sort code.
+func (s *Checker) rulesFor(sortCode string) []rule {
+	code, _ := strconv.Atoi(sortCode)
+
+	var rules []rule
+	for _, ru := range s.rules {
+		if code >= ru.start && code <= ru.end {
+			rules = append(rules, ru)
+		}
+	}
+	return rules
+}
+
+// checkShifted implements exception 14: if the check fails and the last digit of the account number
+// is 0, 1 or 9, the last digit is dropped and the account number is checked again with a leading zero.
+func (s *Checker) checkShifted(ru rule, sortCode, accountNumber string) bool {
+	switch accountNumber[accountLength-1] {
+	case '0', '1', '9':
+	default:
+		return false
+	}
+	return s.check(rule{method: MethodMod11, weights: ru.weights}, sortCode, "0"+accountNumber[:accountLength-1])
+}
+
+// check runs a single check of the weight table.
+func (s *Checker) check(ru rule, sortCode, accountNumber string) bool {
+	switch ru.exception {
+	case exceptionSubstitute:
+		if sub, ok := s.substitutions[sortCode]; ok {
+			sortCode = sub
+		}
+	case exceptionSortCode090126:
+		sortCode = "090126"
+	}
+
+	digits := toDigits(sortCode + accountNumber)
+	weights := weightsFor(ru, digits)
+
+	var total int
+	for i, d := range digits {
+		product := d * weights[i]
+		if ru.method == MethodDblAl {
+			// the digits of the products are added individually
+			product = product/10 + product%10
+		}
+		total += product
+	}
+	return passes(ru, digits, total)
+}
+
+// weightsFor returns the weights of the rule adjusted by its exception.
+func weightsFor(ru rule, digits []int) [weightCount]int {
+	weights := ru.weights
+	switch ru.exception {
+	case exceptionAlternateWeight:
+		if digits[posA] != 0 {
+			weights = [weightCount]int{0, 0, 1, 2, 5, 3, 6, 4, 8, 7, 10, 9, 3, 1}
+			if digits[posG] == 9 {
+				weights = [weightCount]int{0, 0, 0, 0, 0, 0, 0, 0, 8, 7, 10, 9, 3, 1}
+			}
+		}
+	case exceptionZeroiseOnG9:
+		if digits[posG] == 9 {
+			zeroise(&weights)
+		}
+	case exceptionZeroiseOnAB:
+		ab := digits[posA]*10 + digits[posB]
+		if (ab == 9 || ab == 99) && digits[posG] == 9 {
+			zeroise(&weights)
+		}
+	}
+	return weights
+}
+
+// passes checks the weighted total according to the method and exception of the rule.
+func passes(ru rule, digits []int, total int) bool {
+	switch {
+	case ru.method == MethodDblAl && ru.exception == exceptionDblAl27:
+		return (total+27)%10 == 0
+	case ru.method == MethodDblAl && ru.exception == exceptionSubstitute:
+		return checkDigit(total%10, 10) == digits[posH]
+	case ru.method == MethodDblAl || ru.method == MethodMod10:
+		return total%10 == 0
+	case ru.exception == exceptionRemainderIsGH:
+		return total%11 == digits[posG]*10+digits[posH]
+	case ru.exception == exceptionSubstitute:
+		// a remainder of 1 would result in the invalid check digit 10
+		return total%11 != 1 && checkDigit(total%11, 11) == digits[posG]
+	}
+	return total%11 == 0
+}
+
+// checkDigit calculates the check digit from the remainder as defined for exception 5.
+func checkDigit(remainder, modulus int) int {
+	if remainder == 0 {
+		return 0
+	}
+	return modulus - remainder
+}
+
+// zeroise sets the weights of the positions u to b to zero.
+func zeroise(weights *[weightCount]int) {
+	for i := 0; i <= posB; i++ {
+		weights[i] = 0
+	}
+}
+
+func toDigits(value string) []int {
+	digits := make([]int, len(value))
+	for i, r := range value {
+		digits[i] = int(r - '0')
+	}
+	return digits
+}
+
+func isDigits(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
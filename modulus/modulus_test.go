@@ -0,0 +1,122 @@
+package modulus
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestChecker_Check(t *testing.T) {
+	checker, err := LoadChecker("testdata/valacdos.txt", "testdata/scsubtab.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		sortCode      string
+		accountNumber string
+		wantErr       error
+	}{
+		{name: "modulus 10 passes", sortCode: "089999", accountNumber: "66374958"},
+		{name: "modulus 10 fails", sortCode: "089999", accountNumber: "66374959", wantErr: ErrCheckFailed},
+		{name: "modulus 11 passes", sortCode: "107999", accountNumber: "88837491"},
+		{name: "modulus 11 fails", sortCode: "107999", accountNumber: "88837493", wantErr: ErrCheckFailed},
+		{name: "modulus 11 and double alternate pass", sortCode: "202959", accountNumber: "76541762"},
+		{name: "modulus 11 passes, double alternate fails", sortCode: "202959", accountNumber: "83612653",
+			wantErr: ErrCheckFailed},
+		{name: "sort code without rules", sortCode: "010203", accountNumber: "12345678"},
+		{name: "exception 1 passes", sortCode: "118765", accountNumber: "15826780"},
+		{name: "exception 1 fails", sortCode: "118765", accountNumber: "93393106", wantErr: ErrCheckFailed},
+		{name: "exception 2 with a = 0", sortCode: "309070", accountNumber: "01003569"},
+		{name: "exception 2 with a != 0 and g != 9", sortCode: "309070", accountNumber: "55330739"},
+		{name: "exception 2 with a != 0 and g = 9", sortCode: "309070", accountNumber: "11236699"},
+		{name: "exception 2 fails, exception 9 passes", sortCode: "309070", accountNumber: "22112936"},
+		{name: "exception 2 and 9 fail", sortCode: "309070", accountNumber: "43683478", wantErr: ErrCheckFailed},
+		{name: "exception 3 with c = 6 skips second check", sortCode: "820000", accountNumber: "80642357"},
+		{name: "exception 3 with c != 6 or 9", sortCode: "827101", accountNumber: "00472344", wantErr: ErrCheckFailed},
+		{name: "exception 4 remainder equals gh", sortCode: "134020", accountNumber: "15326409"},
+		{name: "exception 5 passes", sortCode: "938611", accountNumber: "06445482"},
+		{name: "exception 5 with substituted sort code", sortCode: "938600", accountNumber: "23470950"},
+		{name: "exception 5 with remainders 0", sortCode: "938063", accountNumber: "46261900"},
+		{name: "exception 5 second check digit wrong", sortCode: "938063", accountNumber: "35073174",
+			wantErr: ErrCheckFailed},
+		{name: "exception 6 foreign currency account", sortCode: "200915", accountNumber: "53326766"},
+		{name: "exception 7 with g = 9", sortCode: "772798", accountNumber: "73187194"},
+		{name: "exception 8 substitutes sort code", sortCode: "086090", accountNumber: "83433072"},
+		{name: "exception 10 fails, exception 11 passes", sortCode: "871427", accountNumber: "85947046"},
+		{name: "exception 10 with ab = 09 and g = 9", sortCode: "871427", accountNumber: "09508799"},
+		{name: "exception 10 and 11 fail", sortCode: "871427", accountNumber: "80900074", wantErr: ErrCheckFailed},
+		{name: "exception 12 fails, exception 13 passes", sortCode: "074456", accountNumber: "75449052"},
+		{name: "exception 14 passes with shifted account", sortCode: "180002", accountNumber: "03172179"},
+		{name: "exception 14 fails", sortCode: "180002", accountNumber: "41876592", wantErr: ErrCheckFailed},
+		{name: "sort code too short", sortCode: "08999", accountNumber: "66374958", wantErr: ErrInvalidFormat},
+		{name: "account number with letters", sortCode: "089999", accountNumber: "6637495A", wantErr: ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			err := checker.Check(tt.sortCode, tt.accountNumber)
+			assert.True(errors.Is(err, tt.wantErr))
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+			}
+		})
+	}
+}
+
+func TestNewChecker(t *testing.T) {
+	tests := []struct {
+		name          string
+		weights       string
+		substitutions string
+		wantErr       error
+	}{
+		{
+			name:    "valid without substitutions",
+			weights: "089000 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1\n\n",
+		},
+		{
+			name:          "valid with substitutions",
+			weights:       "938000 938696 MOD11 7 6 5 4 3 2 7 6 5 4 3 2 0 0 5",
+			substitutions: "938600 938611",
+		},
+		{
+			name:    "missing weights",
+			weights: "089000 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7",
+			wantErr: ErrInvalidTable,
+		},
+		{
+			name:    "unknown method",
+			weights: "089000 089999 MOD12 0 0 0 0 0 0 7 1 3 7 1 3 7 1",
+			wantErr: ErrInvalidTable,
+		},
+		{
+			name:    "invalid exception",
+			weights: "089000 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1 x",
+			wantErr: ErrInvalidTable,
+		},
+		{
+			name:          "invalid substitution",
+			weights:       "089000 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1",
+			substitutions: "938600",
+			wantErr:       ErrInvalidTable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			var substitutions io.Reader
+			if tt.substitutions != "" {
+				substitutions = strings.NewReader(tt.substitutions)
+			}
+			_, err := NewChecker(strings.NewReader(tt.weights), substitutions)
+			assert.True(errors.Is(err, tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tehsphinx/form3/iso"
+)
+
+const (
+	paymentsPath = "/v1/transaction/payments"
+	// dateLayout is the format of dates like the processing date.
+	dateLayout = "2006-01-02"
+	// amountPattern matches positive decimals like the amount of a payment.
+	amountPattern = `^(0|[1-9]\d*)(\.(\d+))?$`
+)
+
+// client side payment validation errors
+var (
+	ErrInvalidAmount         = errors.New("amount should be a positive decimal within the minor units of the currency")
+	ErrInvalidCurrency       = errors.New("currency should be an ISO 4217 currency code")
+	ErrInvalidProcessingDate = errors.New("processingDate should be a date formatted as YYYY-MM-DD")
+)
+
+// Payment holds payment attributes.
+type Payment struct {
+	baseAttr
+
+	// Amount is the decimal amount of the payment in the currency, e.g. "100.21". See FormatAmount.
+	Amount string `json:"amount"`
+	// Currency is the ISO 4217 code of the currency.
+	Currency         string        `json:"currency"`
+	DebtorParty      *PaymentParty `json:"debtor_party,omitempty"`
+	BeneficiaryParty *PaymentParty `json:"beneficiary_party,omitempty"`
+	// PaymentScheme the payment is sent with, e.g. "FPS".
+	PaymentScheme string `json:"payment_scheme,omitempty"`
+	// PaymentType, e.g. "Credit".
+	PaymentType string `json:"payment_type,omitempty"`
+	// SchemePaymentType and SchemePaymentSubType are scheme specific, e.g. "ImmediatePayment" and "InternetBanking".
+	SchemePaymentType    string `json:"scheme_payment_type,omitempty"`
+	SchemePaymentSubType string `json:"scheme_payment_sub_type,omitempty"`
+	// ProcessingDate is the date the payment is processed formatted as YYYY-MM-DD.
+	ProcessingDate    string `json:"processing_date,omitempty"`
+	Reference         string `json:"reference,omitempty"`
+	EndToEndReference string `json:"end_to_end_reference,omitempty"`
+	NumericReference  string `json:"numeric_reference,omitempty"`
+	PaymentPurpose    string `json:"payment_purpose,omitempty"`
+}
+
+// PaymentParty holds the account details of the debtor or beneficiary of a payment.
+type PaymentParty struct {
+	AccountName       string   `json:"account_name,omitempty"`
+	AccountNumber     string   `json:"account_number,omitempty"`
+	AccountNumberCode string   `json:"account_number_code,omitempty"`
+	AccountWith       string   `json:"account_with,omitempty"`
+	Address           []string `json:"address,omitempty"`
+	BankID            string   `json:"bank_id,omitempty"`
+	BankIDCode        string   `json:"bank_id_code,omitempty"`
+	Name              string   `json:"name,omitempty"`
+}
+
+// CreatePayment creates a new payment. By default a random id is generated for the payment.
+// Use WithID or WithDeterministicID to control the id, so a create can be safely repeated:
+// if a payment with that id already exists in the organisation, it is fetched and returned instead.
+func (s *Client) CreatePayment(ctx context.Context, orgID string, data *Payment,
+	options ...CreateOption) (*Payment, error) {
+	if err := s.validatePayment(data); err != nil {
+		return nil, fmt.Errorf("invalid Payment information provided: %w", err)
+	}
+
+	resp := &Payment{}
+	if err := s.create(ctx, paymentsPath, typePayments, orgID, data, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FetchPayment retrieves the payment with given payment id.
+func (s *Client) FetchPayment(ctx context.Context, uid string) (*Payment, error) {
+	resp := &Payment{}
+	uri := s.buildURL(paymentsPath, uid, nil)
+	if err := s.request(ctx, uri, typePayments, withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListPayments retrieves a list of payments. It has pagination (e.g. WithPageSize).
+func (s *Client) ListPayments(ctx context.Context, opts ...ListOption) ([]Payment, error) {
+	params := url.Values{}
+	applyOptions(params, opts)
+
+	var payments []Payment
+	uri := s.buildURL(paymentsPath, "", params)
+	if err := s.request(ctx, uri, typePayments,
+		withListResp(
+			func() responseFiller {
+				return &Payment{}
+			},
+			func(data responseFiller) {
+				payment := data.(*Payment)
+				payments = append(payments, *payment)
+			},
+		),
+	); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// DeletePayment deletes the payment with given payment id. If the resource was not found a
+// ErrNotFound will be returned. A ErrConflict indicates the payment was updated meanwhile.
+func (s *Client) DeletePayment(ctx context.Context, uid string, version int) error {
+	params := url.Values{}
+	params.Set("version", strconv.Itoa(version))
+
+	uri := s.buildURL(paymentsPath, uid, params)
+	return s.request(ctx, uri, typePayments,
+		withMethod(http.MethodDelete), withStatusOk(http.StatusNoContent))
+}
+
+// FormatAmount formats an amount given in minor units of the currency (e.g. pence) as decimal
+// amount as used by Payment.Amount: `FormatAmount(10021, "GBP")` returns "100.21".
+func FormatAmount(minorUnits int64, currency string) (string, error) {
+	units, ok := iso.MinorUnits(currency)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	if minorUnits < 0 {
+		return "", fmt.Errorf("%w: %d", ErrInvalidAmount, minorUnits)
+	}
+
+	amount := strconv.FormatInt(minorUnits, 10)
+	if units == 0 {
+		return amount, nil
+	}
+	if len(amount) <= units {
+		amount = strings.Repeat("0", units-len(amount)+1) + amount
+	}
+	return amount[:len(amount)-units] + "." + amount[len(amount)-units:], nil
+}
+
+func getValidatePayment() func(attr *Payment) error {
+	amountRE := regexp.MustCompile(amountPattern)
+
+	return func(attr *Payment) error {
+		var errs ValidationErrors
+		if !iso.IsCurrency(attr.Currency) {
+			errs.add(attrPath("Currency", "currency"), attr.Currency, ErrInvalidCurrency)
+		}
+		if !validAmount(amountRE, attr.Amount, attr.Currency) {
+			errs.add(attrPath("Amount", "amount"), attr.Amount, ErrInvalidAmount)
+		}
+		if attr.ProcessingDate != "" && !validDate(attr.ProcessingDate) {
+			errs.add(attrPath("ProcessingDate", "processing_date"), attr.ProcessingDate, ErrInvalidProcessingDate)
+		}
+		return errs.err()
+	}
+}
+
+// validAmount checks that the amount is a positive decimal. The number of decimals is only checked
+// if the currency is valid.
+func validAmount(amountRE *regexp.Regexp, amount, currency string) bool {
+	match := amountRE.FindStringSubmatch(amount)
+	if match == nil || strings.Trim(amount, "0.") == "" {
+		return false
+	}
+
+	units, ok := iso.MinorUnits(currency)
+	return !ok || len(match[3]) <= units
+}
+
+// validDate checks that the value is a date formatted as YYYY-MM-DD.
+func validDate(value string) bool {
+	_, err := time.Parse(dateLayout, value)
+	return err == nil
+}
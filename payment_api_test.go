@@ -0,0 +1,180 @@
+package form3_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+	"github.com/tehsphinx/form3"
+	"github.com/tehsphinx/form3/form3test"
+)
+
+// TestClient_payments runs the payment lifecycle against the fake server, as the accountapi image
+// of the docker-compose stack only serves accounts.
+func TestClient_payments(t *testing.T) {
+	assert := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	srv := form3test.NewServer()
+	defer srv.Close()
+	cl := form3.NewClient(srv.URL)
+
+	data := &form3.Payment{
+		Amount:         "100.21",
+		Currency:       "GBP",
+		PaymentScheme:  "FPS",
+		PaymentType:    "Credit",
+		ProcessingDate: "2026-10-17",
+		Reference:      "Payment for Em's piano lessons",
+		DebtorParty: &form3.PaymentParty{
+			AccountName: "EJ Brown Black", AccountNumber: "GB29XABC10161234567801",
+			AccountNumberCode: "IBAN", BankID: "203301", BankIDCode: "GBDSC",
+		},
+		BeneficiaryParty: &form3.PaymentParty{
+			AccountName: "W Owens", AccountNumber: "31926819", BankID: "403000", BankIDCode: "GBDSC",
+		},
+	}
+
+	uid := uuid.New().String()
+	created, err := cl.CreatePayment(ctx, orgID, data, form3.WithID(uid))
+	assert.NoErr(err)
+	assert.Equal(created.ID(), uid)
+	assert.Equal(created.Amount, data.Amount)
+	assert.Equal(created.BeneficiaryParty, data.BeneficiaryParty)
+	assert.Equal(srv.PaymentCount(), 1)
+
+	// creating the payment a second time returns the existing payment
+	again, err := cl.CreatePayment(ctx, orgID, data, form3.WithID(uid))
+	assert.NoErr(err)
+	assert.Equal(again.ID(), uid)
+	assert.Equal(srv.PaymentCount(), 1)
+
+	fetched, err := cl.FetchPayment(ctx, uid)
+	assert.NoErr(err)
+	assert.Equal(fetched.Reference, data.Reference)
+	assert.Equal(fetched.DebtorParty, data.DebtorParty)
+
+	payments, err := cl.ListPayments(ctx, form3.WithPageSize(10))
+	assert.NoErr(err)
+	assert.Equal(len(payments), 1)
+
+	// invalid payments are rejected before they are sent
+	_, err = cl.CreatePayment(ctx, orgID, &form3.Payment{Amount: "1.001", Currency: "GBP"})
+	assert.True(errors.Is(err, form3.ErrInvalidAmount))
+
+	err = cl.DeletePayment(ctx, uid, created.Version())
+	assert.NoErr(err)
+
+	_, err = cl.FetchPayment(ctx, uid)
+	assert.True(errors.Is(err, form3.ErrNotFound))
+	assert.Equal(srv.PaymentCount(), 0)
+}
+
+func TestClient_paymentSubmissions(t *testing.T) {
+	assert := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	srv := form3test.NewServer()
+	defer srv.Close()
+	cl := form3.NewClient(srv.URL, form3.WithStrictEnums())
+
+	payment, err := cl.CreatePayment(ctx, orgID, &form3.Payment{Amount: "12.50", Currency: "GBP"})
+	assert.NoErr(err)
+
+	submission, err := cl.SubmitPayment(ctx, orgID, payment.ID())
+	assert.NoErr(err)
+	assert.Equal(submission.Status, form3.SubmissionStatusAccepted)
+	assert.Equal(submission.Type(), "payment_submissions")
+
+	// submitting again with the same id returns the existing submission
+	again, err := cl.SubmitPayment(ctx, orgID, payment.ID(), form3.WithID(submission.ID()))
+	assert.NoErr(err)
+	assert.Equal(again.ID(), submission.ID())
+
+	// follow the payment through the scheme
+	assert.NoErr(srv.SetSubmissionStatus(payment.ID(), submission.ID(), "delivery_confirmed"))
+	submission, err = cl.FetchPaymentSubmission(ctx, payment.ID(), submission.ID())
+	assert.NoErr(err)
+	assert.Equal(submission.Status, form3.SubmissionStatusDeliveryConfirmed)
+	assert.True(submission.Status.IsFinal())
+
+	admissionID, err := srv.AdmitPayment(payment.ID(), "confirmed")
+	assert.NoErr(err)
+	admission, err := cl.FetchPaymentAdmission(ctx, payment.ID(), admissionID)
+	assert.NoErr(err)
+	assert.Equal(admission.Status, form3.AdmissionStatusConfirmed)
+	assert.Equal(admission.OrganisationID(), orgID)
+
+	_, err = cl.SubmitPayment(ctx, orgID, uuid.New().String())
+	assert.True(errors.Is(err, form3.ErrNotFound))
+	_, err = cl.FetchPaymentAdmission(ctx, payment.ID(), uuid.New().String())
+	assert.True(errors.Is(err, form3.ErrNotFound))
+}
+
+func TestClient_paymentReturns(t *testing.T) {
+	assert := is.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	srv := form3test.NewServer()
+	defer srv.Close()
+	cl := form3.NewClient(srv.URL, form3.WithStrictEnums())
+
+	payment, err := cl.CreatePayment(ctx, orgID, &form3.Payment{Amount: "12.50", Currency: "GBP"})
+	assert.NoErr(err)
+
+	// return
+	ret, err := cl.CreateReturn(ctx, orgID, payment.ID(), &form3.Return{ReturnCode: form3.ReturnCodeClosedAccount})
+	assert.NoErr(err)
+	assert.Equal(ret.Type(), "returns")
+	ret, err = cl.FetchReturn(ctx, payment.ID(), ret.ID())
+	assert.NoErr(err)
+	assert.Equal(ret.ReturnCode, form3.ReturnCodeClosedAccount)
+	submission, err := cl.SubmitReturn(ctx, orgID, payment.ID(), ret.ID())
+	assert.NoErr(err)
+	assert.Equal(submission.Type(), "return_submissions")
+	assert.Equal(submission.Status, form3.SubmissionStatusAccepted)
+
+	// reversal
+	reversal, err := cl.CreateReversal(ctx, orgID, payment.ID(), &form3.Reversal{})
+	assert.NoErr(err)
+	reversal, err = cl.FetchReversal(ctx, payment.ID(), reversal.ID())
+	assert.NoErr(err)
+	submission, err = cl.SubmitReversal(ctx, orgID, payment.ID(), reversal.ID())
+	assert.NoErr(err)
+	assert.Equal(submission.Type(), "reversal_submissions")
+
+	// recall, created twice with the same id
+	data := &form3.Recall{Reason: form3.RecallReasonFraud, ReasonDescription: "reported by customer"}
+	recall, err := cl.CreateRecall(ctx, orgID, payment.ID(), data)
+	assert.NoErr(err)
+	again, err := cl.CreateRecall(ctx, orgID, payment.ID(), data, form3.WithID(recall.ID()))
+	assert.NoErr(err)
+	assert.Equal(again.ID(), recall.ID())
+	recall, err = cl.FetchRecall(ctx, payment.ID(), recall.ID())
+	assert.NoErr(err)
+	assert.Equal(recall.Reason, form3.RecallReasonFraud)
+	assert.Equal(recall.ReasonDescription, data.ReasonDescription)
+	submission, err = cl.SubmitRecall(ctx, orgID, payment.ID(), recall.ID())
+	assert.NoErr(err)
+	assert.Equal(submission.Type(), "recall_submissions")
+
+	// reason codes are validated before sending
+	_, err = cl.CreateReturn(ctx, orgID, payment.ID(), &form3.Return{ReturnCode: "FRAD"})
+	assert.True(errors.Is(err, form3.ErrInvalidReturnCode))
+	_, err = cl.CreateReversal(ctx, orgID, payment.ID(), &form3.Reversal{Reason: "XXXX"})
+	assert.True(errors.Is(err, form3.ErrInvalidReversalReason))
+	_, err = cl.CreateRecall(ctx, orgID, payment.ID(), &form3.Recall{})
+	assert.True(errors.Is(err, form3.ErrInvalidRecallReason))
+
+	// the parent payment and the return must exist
+	_, err = cl.CreateReturn(ctx, orgID, uuid.New().String(), &form3.Return{ReturnCode: form3.ReturnCodeDuplication})
+	assert.True(errors.Is(err, form3.ErrNotFound))
+	_, err = cl.SubmitReturn(ctx, orgID, payment.ID(), uuid.New().String())
+	assert.True(errors.Is(err, form3.ErrNotFound))
+}
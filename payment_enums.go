@@ -0,0 +1,232 @@
+package form3
+
+import "fmt"
+
+// SubmissionStatus is the status of a payment submission on its way through the scheme.
+type SubmissionStatus string
+
+// submission statuses
+const (
+	SubmissionStatusAccepted          SubmissionStatus = "accepted"
+	SubmissionStatusValidationPending SubmissionStatus = "validation_pending"
+	SubmissionStatusLimitCheckPending SubmissionStatus = "limit_check_pending"
+	SubmissionStatusLimitCheckPassed  SubmissionStatus = "limit_check_passed"
+	SubmissionStatusLimitCheckFailed  SubmissionStatus = "limit_check_failed"
+	SubmissionStatusReleased          SubmissionStatus = "released_to_gateway"
+	SubmissionStatusQueued            SubmissionStatus = "queued_for_delivery"
+	SubmissionStatusSubmitted         SubmissionStatus = "submitted"
+	SubmissionStatusDeliveryConfirmed SubmissionStatus = "delivery_confirmed"
+	SubmissionStatusDeliveryFailed    SubmissionStatus = "delivery_failed"
+)
+
+func submissionStatuses() []SubmissionStatus {
+	return []SubmissionStatus{
+		SubmissionStatusAccepted, SubmissionStatusValidationPending,
+		SubmissionStatusLimitCheckPending, SubmissionStatusLimitCheckPassed, SubmissionStatusLimitCheckFailed,
+		SubmissionStatusReleased, SubmissionStatusQueued, SubmissionStatusSubmitted,
+		SubmissionStatusDeliveryConfirmed, SubmissionStatusDeliveryFailed,
+	}
+}
+
+// IsValid checks if the status is one of the known statuses.
+func (s SubmissionStatus) IsValid() bool {
+	for _, value := range submissionStatuses() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal reports if the submission reached a final status, i.e. the status will not change anymore.
+func (s SubmissionStatus) IsFinal() bool {
+	switch s {
+	case SubmissionStatusDeliveryConfirmed, SubmissionStatusDeliveryFailed, SubmissionStatusLimitCheckFailed:
+		return true
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s SubmissionStatus) String() string {
+	return string(s)
+}
+
+// AdmissionStatus is the status of the admission of an incoming payment.
+type AdmissionStatus string
+
+// admission statuses
+const (
+	AdmissionStatusConfirmed AdmissionStatus = "confirmed"
+	AdmissionStatusFailed    AdmissionStatus = "failed"
+)
+
+func admissionStatuses() []AdmissionStatus {
+	return []AdmissionStatus{AdmissionStatusConfirmed, AdmissionStatusFailed}
+}
+
+// IsValid checks if the status is one of the known statuses.
+func (s AdmissionStatus) IsValid() bool {
+	for _, value := range admissionStatuses() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s AdmissionStatus) String() string {
+	return string(s)
+}
+
+// checkEnums implements the enumChecker interface. See WithStrictEnums.
+func (s *PaymentSubmission) checkEnums() error {
+	if s.Status != "" && !s.Status.IsValid() {
+		return fmt.Errorf("%w: status %q", ErrUnknownEnumValue, s.Status)
+	}
+	return nil
+}
+
+// checkEnums implements the enumChecker interface. See WithStrictEnums.
+func (s *PaymentAdmission) checkEnums() error {
+	if s.Status != "" && !s.Status.IsValid() {
+		return fmt.Errorf("%w: status %q", ErrUnknownEnumValue, s.Status)
+	}
+	return nil
+}
+
+// ReturnCode is the reason a payment is returned to the sender.
+type ReturnCode string
+
+// return codes
+const (
+	ReturnCodeIncorrectAccountNumber ReturnCode = "AC01"
+	ReturnCodeClosedAccount          ReturnCode = "AC04"
+	ReturnCodeBlockedAccount         ReturnCode = "AC06"
+	ReturnCodeTransactionForbidden   ReturnCode = "AG01"
+	ReturnCodeInsufficientFunds      ReturnCode = "AM04"
+	ReturnCodeDuplication            ReturnCode = "AM05"
+	ReturnCodeMissingCreditorAddress ReturnCode = "BE04"
+	ReturnCodeFollowingCancellation  ReturnCode = "FOCR"
+	ReturnCodeNoMandate              ReturnCode = "MD01"
+	ReturnCodeEndCustomerDeceased    ReturnCode = "MD07"
+	ReturnCodeCustomerReason         ReturnCode = "MS02"
+	ReturnCodeAgentReason            ReturnCode = "MS03"
+	ReturnCodeRegulatoryReason       ReturnCode = "RR04"
+)
+
+func returnCodes() []ReturnCode {
+	return []ReturnCode{
+		ReturnCodeIncorrectAccountNumber, ReturnCodeClosedAccount, ReturnCodeBlockedAccount,
+		ReturnCodeTransactionForbidden, ReturnCodeInsufficientFunds, ReturnCodeDuplication,
+		ReturnCodeMissingCreditorAddress, ReturnCodeFollowingCancellation, ReturnCodeNoMandate,
+		ReturnCodeEndCustomerDeceased, ReturnCodeCustomerReason, ReturnCodeAgentReason, ReturnCodeRegulatoryReason,
+	}
+}
+
+// IsValid checks if the code is one of the known return codes.
+func (s ReturnCode) IsValid() bool {
+	for _, value := range returnCodes() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s ReturnCode) String() string {
+	return string(s)
+}
+
+// ReversalReason is the reason a payment is reversed by the sender.
+type ReversalReason string
+
+// reversal reasons
+const (
+	ReversalReasonDuplication    ReversalReason = "AM05"
+	ReversalReasonCustomerReason ReversalReason = "MS02"
+	ReversalReasonAgentReason    ReversalReason = "MS03"
+)
+
+func reversalReasons() []ReversalReason {
+	return []ReversalReason{ReversalReasonDuplication, ReversalReasonCustomerReason, ReversalReasonAgentReason}
+}
+
+// IsValid checks if the reason is one of the known reversal reasons.
+func (s ReversalReason) IsValid() bool {
+	for _, value := range reversalReasons() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s ReversalReason) String() string {
+	return string(s)
+}
+
+// RecallReason is the reason the sender asks the receiver to send back a payment.
+type RecallReason string
+
+// recall reasons
+const (
+	RecallReasonInvalidCreditorAccount RecallReason = "AC03"
+	RecallReasonWrongAmount            RecallReason = "AM09"
+	RecallReasonRequestedByCustomer    RecallReason = "CUST"
+	RecallReasonUnableToApply          RecallReason = "CUTA"
+	RecallReasonDuplicate              RecallReason = "DUPL"
+	RecallReasonFraud                  RecallReason = "FRAD"
+	RecallReasonTechnicalProblem       RecallReason = "TECH"
+	RecallReasonUnduePayment           RecallReason = "UPAY"
+)
+
+func recallReasons() []RecallReason {
+	return []RecallReason{
+		RecallReasonInvalidCreditorAccount, RecallReasonWrongAmount, RecallReasonRequestedByCustomer,
+		RecallReasonUnableToApply, RecallReasonDuplicate, RecallReasonFraud,
+		RecallReasonTechnicalProblem, RecallReasonUnduePayment,
+	}
+}
+
+// IsValid checks if the reason is one of the known recall reasons.
+func (s RecallReason) IsValid() bool {
+	for _, value := range recallReasons() {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements the fmt.Stringer interface.
+func (s RecallReason) String() string {
+	return string(s)
+}
+
+// checkEnums implements the enumChecker interface. See WithStrictEnums.
+func (s *Return) checkEnums() error {
+	if s.ReturnCode != "" && !s.ReturnCode.IsValid() {
+		return fmt.Errorf("%w: return_code %q", ErrUnknownEnumValue, s.ReturnCode)
+	}
+	return nil
+}
+
+// checkEnums implements the enumChecker interface. See WithStrictEnums.
+func (s *Reversal) checkEnums() error {
+	if s.Reason != "" && !s.Reason.IsValid() {
+		return fmt.Errorf("%w: reason %q", ErrUnknownEnumValue, s.Reason)
+	}
+	return nil
+}
+
+// checkEnums implements the enumChecker interface. See WithStrictEnums.
+func (s *Recall) checkEnums() error {
+	if s.Reason != "" && !s.Reason.IsValid() {
+		return fmt.Errorf("%w: reason %q", ErrUnknownEnumValue, s.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package form3
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPaymentEnums_IsValid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{ IsValid() bool }
+		want  bool
+	}{
+		{name: "accepted", value: SubmissionStatusAccepted, want: true},
+		{name: "released", value: SubmissionStatusReleased, want: true},
+		{name: "delivery failed", value: SubmissionStatusDeliveryFailed, want: true},
+		{name: "unknown submission status", value: SubmissionStatus("lost"), want: false},
+		{name: "confirmed", value: AdmissionStatusConfirmed, want: true},
+		{name: "empty admission status", value: AdmissionStatus(""), want: false},
+		{name: "closed account", value: ReturnCodeClosedAccount, want: true},
+		{name: "unknown return code", value: ReturnCode("XX01"), want: false},
+		{name: "duplicate reversal", value: ReversalReasonDuplication, want: true},
+		{name: "recall reason as return code", value: ReturnCode("FRAD"), want: false},
+		{name: "fraud", value: RecallReasonFraud, want: true},
+		{name: "lower case recall reason", value: RecallReason("frad"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+			assert.Equal(tt.value.IsValid(), tt.want)
+		})
+	}
+}
+
+func TestSubmissionStatus_IsFinal(t *testing.T) {
+	assert := is.New(t)
+
+	assert.True(SubmissionStatusDeliveryConfirmed.IsFinal())
+	assert.True(SubmissionStatusDeliveryFailed.IsFinal())
+	assert.True(!SubmissionStatusAccepted.IsFinal())
+	assert.True(!SubmissionStatusReleased.IsFinal())
+}
@@ -0,0 +1,214 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/tehsphinx/form3/iso"
+)
+
+// path segments of the return, reversal and recall sub-resources of a payment
+const (
+	returnsPath   = "returns"
+	reversalsPath = "reversals"
+	recallsPath   = "recalls"
+)
+
+// client side return, reversal and recall validation errors
+var (
+	ErrInvalidReturnCode     = errors.New("returnCode should be a 4 character return code")
+	ErrInvalidReversalReason = errors.New("reason should be a 4 character reversal reason")
+	ErrInvalidRecallReason   = errors.New("reason should be a 4 character recall reason")
+)
+
+// Return sends an inbound payment or a direct debit back, e.g. because the account is closed.
+type Return struct {
+	baseAttr
+
+	ReturnCode ReturnCode `json:"return_code"`
+	// Amount and Currency of the return. If empty the full amount of the payment is returned.
+	Amount   string `json:"amount,omitempty"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// Reversal reverses a payment or direct debit sent in error, e.g. a duplicate.
+type Reversal struct {
+	baseAttr
+
+	Reason ReversalReason `json:"reason,omitempty"`
+}
+
+// Recall asks the receiver of a payment to send it back, e.g. in case of fraud.
+type Recall struct {
+	baseAttr
+
+	Reason            RecallReason `json:"reason"`
+	ReasonDescription string       `json:"reason_description,omitempty"`
+}
+
+// CreateReturn creates a return of the payment with given payment id. Submit it with SubmitReturn.
+func (s *Client) CreateReturn(ctx context.Context, orgID, paymentID string, data *Return,
+	options ...CreateOption) (*Return, error) {
+	if err := s.validateReasons.validateReturn(data); err != nil {
+		return nil, err
+	}
+
+	resp := &Return{}
+	path := resourcePath(paymentsPath, paymentID, returnsPath)
+	if err := s.create(ctx, path, typeReturns, orgID, data, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FetchReturn retrieves the return with given return id of a payment.
+func (s *Client) FetchReturn(ctx context.Context, paymentID, returnID string) (*Return, error) {
+	resp := &Return{}
+	uri := s.buildURL(resourcePath(paymentsPath, paymentID, returnsPath), returnID, nil)
+	if err := s.request(ctx, uri, typeReturns, withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SubmitReturn submits the return to the scheme.
+func (s *Client) SubmitReturn(ctx context.Context, orgID, paymentID, returnID string,
+	options ...CreateOption) (*PaymentSubmission, error) {
+	return s.submit(ctx, orgID, resourcePath(paymentsPath, paymentID, returnsPath, returnID, submissionsPath),
+		typeReturnSubmissions, options)
+}
+
+// CreateReversal creates a reversal of the payment with given payment id. Submit it with SubmitReversal.
+func (s *Client) CreateReversal(ctx context.Context, orgID, paymentID string, data *Reversal,
+	options ...CreateOption) (*Reversal, error) {
+	if err := s.validateReasons.validateReversal(data); err != nil {
+		return nil, err
+	}
+
+	resp := &Reversal{}
+	path := resourcePath(paymentsPath, paymentID, reversalsPath)
+	if err := s.create(ctx, path, typeReversals, orgID, data, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FetchReversal retrieves the reversal with given reversal id of a payment.
+func (s *Client) FetchReversal(ctx context.Context, paymentID, reversalID string) (*Reversal, error) {
+	resp := &Reversal{}
+	uri := s.buildURL(resourcePath(paymentsPath, paymentID, reversalsPath), reversalID, nil)
+	if err := s.request(ctx, uri, typeReversals, withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SubmitReversal submits the reversal to the scheme.
+func (s *Client) SubmitReversal(ctx context.Context, orgID, paymentID, reversalID string,
+	options ...CreateOption) (*PaymentSubmission, error) {
+	return s.submit(ctx, orgID, resourcePath(paymentsPath, paymentID, reversalsPath, reversalID, submissionsPath),
+		typeReversalSubmissions, options)
+}
+
+// CreateRecall creates a recall of the payment with given payment id. Submit it with SubmitRecall.
+func (s *Client) CreateRecall(ctx context.Context, orgID, paymentID string, data *Recall,
+	options ...CreateOption) (*Recall, error) {
+	if err := s.validateReasons.validateRecall(data); err != nil {
+		return nil, err
+	}
+
+	resp := &Recall{}
+	path := resourcePath(paymentsPath, paymentID, recallsPath)
+	if err := s.create(ctx, path, typeRecalls, orgID, data, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FetchRecall retrieves the recall with given recall id of a payment.
+func (s *Client) FetchRecall(ctx context.Context, paymentID, recallID string) (*Recall, error) {
+	resp := &Recall{}
+	uri := s.buildURL(resourcePath(paymentsPath, paymentID, recallsPath), recallID, nil)
+	if err := s.request(ctx, uri, typeRecalls, withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SubmitRecall submits the recall to the scheme.
+func (s *Client) SubmitRecall(ctx context.Context, orgID, paymentID, recallID string,
+	options ...CreateOption) (*PaymentSubmission, error) {
+	return s.submit(ctx, orgID, resourcePath(paymentsPath, paymentID, recallsPath, recallID, submissionsPath),
+		typeRecallSubmissions, options)
+}
+
+// reasonValidator validates returns, reversals and recalls. Reason codes unknown to the client are only
+// rejected with WithStrictEnums, so codes introduced by the schemes can be used without updating the client.
+type reasonValidator struct {
+	strict   bool
+	codeRE   *regexp.Regexp
+	amountRE *regexp.Regexp
+}
+
+func newReasonValidator(strict bool) reasonValidator {
+	return reasonValidator{
+		strict:   strict,
+		codeRE:   regexp.MustCompile(`^[A-Z0-9]{4}$`),
+		amountRE: regexp.MustCompile(amountPattern),
+	}
+}
+
+// validCode checks the format of a reason code and, if strict, that it is known to the client.
+func (s reasonValidator) validCode(code string, known bool) bool {
+	if s.strict {
+		return known
+	}
+	return s.codeRE.MatchString(code)
+}
+
+func (s reasonValidator) validateReturn(data *Return) error {
+	var errs ValidationErrors
+	if !s.validCode(data.ReturnCode.String(), data.ReturnCode.IsValid()) {
+		errs.add(attrPath("ReturnCode", "return_code"), data.ReturnCode.String(), ErrInvalidReturnCode)
+	}
+	if (data.Amount != "" || data.Currency != "") && !iso.IsCurrency(data.Currency) {
+		errs.add(attrPath("Currency", "currency"), data.Currency, ErrInvalidCurrency)
+	}
+	if data.Amount != "" && !validAmount(s.amountRE, data.Amount, data.Currency) {
+		errs.add(attrPath("Amount", "amount"), data.Amount, ErrInvalidAmount)
+	}
+	if err := errs.err(); err != nil {
+		return fmt.Errorf("invalid Return information provided: %w", err)
+	}
+	return nil
+}
+
+func (s reasonValidator) validateReversal(data *Reversal) error {
+	var errs ValidationErrors
+	if data.Reason != "" && !s.validCode(data.Reason.String(), data.Reason.IsValid()) {
+		errs.add(attrPath("Reason", "reason"), data.Reason.String(), ErrInvalidReversalReason)
+	}
+	if err := errs.err(); err != nil {
+		return fmt.Errorf("invalid Reversal information provided: %w", err)
+	}
+	return nil
+}
+
+func (s reasonValidator) validateRecall(data *Recall) error {
+	var errs ValidationErrors
+	if !s.validCode(data.Reason.String(), data.Reason.IsValid()) {
+		errs.add(attrPath("Reason", "reason"), data.Reason.String(), ErrInvalidRecallReason)
+	}
+	if err := errs.err(); err != nil {
+		return fmt.Errorf("invalid Recall information provided: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package form3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func Test_reasonValidator(t *testing.T) {
+	tests := []struct {
+		name     string
+		strict   bool
+		validate func(v reasonValidator) error
+		wantErr  error
+	}{
+		{
+			name:     "known return code",
+			validate: func(v reasonValidator) error { return v.validateReturn(&Return{ReturnCode: ReturnCodeClosedAccount}) },
+		},
+		{
+			name:     "unknown return code",
+			validate: func(v reasonValidator) error { return v.validateReturn(&Return{ReturnCode: "AC13"}) },
+		},
+		{
+			name:     "unknown return code with strict enums",
+			strict:   true,
+			validate: func(v reasonValidator) error { return v.validateReturn(&Return{ReturnCode: "AC13"}) },
+			wantErr:  ErrInvalidReturnCode,
+		},
+		{
+			name:     "malformed return code",
+			validate: func(v reasonValidator) error { return v.validateReturn(&Return{ReturnCode: "closed"}) },
+			wantErr:  ErrInvalidReturnCode,
+		},
+		{
+			name: "partial return",
+			validate: func(v reasonValidator) error {
+				return v.validateReturn(&Return{ReturnCode: ReturnCodeDuplication, Amount: "10.50", Currency: "GBP"})
+			},
+		},
+		{
+			name: "return amount with too many decimals",
+			validate: func(v reasonValidator) error {
+				return v.validateReturn(&Return{ReturnCode: ReturnCodeDuplication, Amount: "10.505", Currency: "GBP"})
+			},
+			wantErr: ErrInvalidAmount,
+		},
+		{
+			name: "return amount without currency",
+			validate: func(v reasonValidator) error {
+				return v.validateReturn(&Return{ReturnCode: ReturnCodeDuplication, Amount: "10.50"})
+			},
+			wantErr: ErrInvalidCurrency,
+		},
+		{
+			name:     "reversal without reason",
+			validate: func(v reasonValidator) error { return v.validateReversal(&Reversal{}) },
+		},
+		{
+			name:     "unknown reversal reason with strict enums",
+			strict:   true,
+			validate: func(v reasonValidator) error { return v.validateReversal(&Reversal{Reason: "FRAD"}) },
+			wantErr:  ErrInvalidReversalReason,
+		},
+		{
+			name:     "unknown recall reason",
+			validate: func(v reasonValidator) error { return v.validateRecall(&Recall{Reason: "AC14"}) },
+		},
+		{
+			name:     "recall without reason",
+			validate: func(v reasonValidator) error { return v.validateRecall(&Recall{}) },
+			wantErr:  ErrInvalidRecallReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			err := tt.validate(newReasonValidator(tt.strict))
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+				return
+			}
+			assert.True(errors.Is(err, tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package form3
+
+import "context"
+
+// path segments of the payment sub-resources
+const (
+	submissionsPath = "submissions"
+	admissionsPath  = "admissions"
+)
+
+// PaymentSubmission is the submission of a payment (or of its return, reversal or recall) or a mandate to the scheme.
+// Its status tracks the payment on its way through the scheme.
+type PaymentSubmission struct {
+	baseAttr
+
+	Status       SubmissionStatus `json:"status,omitempty"`
+	StatusReason string           `json:"status_reason,omitempty"`
+	// SchemeStatusCode is the status code reported by the scheme, e.g. in case of a rejection.
+	SchemeStatusCode            string `json:"scheme_status_code,omitempty"`
+	SchemeStatusCodeDescription string `json:"scheme_status_code_description,omitempty"`
+	// SubmissionDateTime is the time the payment was submitted to the scheme (RFC 3339).
+	SubmissionDateTime string `json:"submission_datetime,omitempty"`
+}
+
+// PaymentAdmission is the admission of an incoming payment, i.e. the payment was received from the scheme.
+type PaymentAdmission struct {
+	baseAttr
+
+	Status           AdmissionStatus `json:"status,omitempty"`
+	StatusReason     string          `json:"status_reason,omitempty"`
+	SchemeStatusCode string          `json:"scheme_status_code,omitempty"`
+	// AdmissionDateTime is the time the payment was admitted (RFC 3339).
+	AdmissionDateTime string `json:"admission_datetime,omitempty"`
+	// SettlementDate is the date the payment was settled formatted as YYYY-MM-DD.
+	SettlementDate  string `json:"settlement_date,omitempty"`
+	SettlementCycle int    `json:"settlement_cycle,omitempty"`
+}
+
+// SubmitPayment submits the payment with given payment id to the scheme. The returned submission
+// can be fetched again with FetchPaymentSubmission to follow the status of the payment.
+// Like CreatePayment a submission with an id given by WithID or WithDeterministicID can be safely repeated.
+func (s *Client) SubmitPayment(ctx context.Context, orgID, paymentID string,
+	options ...CreateOption) (*PaymentSubmission, error) {
+	return s.submit(ctx, orgID, resourcePath(paymentsPath, paymentID, submissionsPath),
+		typePaymentSubmissions, options)
+}
+
+// submit creates a submission at the submissions path of a payment or one of its sub-resources.
+func (s *Client) submit(ctx context.Context, orgID, path string, typ attrType,
+	options []CreateOption) (*PaymentSubmission, error) {
+	resp := &PaymentSubmission{}
+	if err := s.create(ctx, path, typ, orgID, &PaymentSubmission{}, resp, options); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FetchPaymentSubmission retrieves the submission with given submission id of a payment.
+func (s *Client) FetchPaymentSubmission(ctx context.Context, paymentID,
+	submissionID string) (*PaymentSubmission, error) {
+	resp := &PaymentSubmission{}
+	uri := s.buildURL(resourcePath(paymentsPath, paymentID, submissionsPath), submissionID, nil)
+	if err := s.request(ctx, uri, typePaymentSubmissions, withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FetchPaymentAdmission retrieves the admission with given admission id of an incoming payment.
+func (s *Client) FetchPaymentAdmission(ctx context.Context, paymentID, admissionID string) (*PaymentAdmission, error) {
+	resp := &PaymentAdmission{}
+	uri := s.buildURL(resourcePath(paymentsPath, paymentID, admissionsPath), admissionID, nil)
+	if err := s.request(ctx, uri, typePaymentAdmissions, withResp(resp)); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
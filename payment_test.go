@@ -0,0 +1,79 @@
+package form3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func Test_getValidatePayment(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment *Payment
+		wantErr error
+	}{
+		{name: "valid", payment: &Payment{Amount: "100.21", Currency: "GBP", ProcessingDate: "2026-10-17"}},
+		{name: "valid without decimals", payment: &Payment{Amount: "100", Currency: "GBP"}},
+		{name: "valid JPY", payment: &Payment{Amount: "1500", Currency: "JPY"}},
+		{name: "invalid currency", payment: &Payment{Amount: "100.21", Currency: "XYZ"}, wantErr: ErrInvalidCurrency},
+		{name: "zero amount", payment: &Payment{Amount: "0.00", Currency: "GBP"}, wantErr: ErrInvalidAmount},
+		{name: "negative amount", payment: &Payment{Amount: "-1.00", Currency: "GBP"}, wantErr: ErrInvalidAmount},
+		{name: "leading zero", payment: &Payment{Amount: "01.00", Currency: "GBP"}, wantErr: ErrInvalidAmount},
+		{name: "too many decimals", payment: &Payment{Amount: "1.001", Currency: "GBP"}, wantErr: ErrInvalidAmount},
+		{name: "decimals for JPY", payment: &Payment{Amount: "1.5", Currency: "JPY"}, wantErr: ErrInvalidAmount},
+		{name: "invalid processing date", payment: &Payment{Amount: "1", Currency: "GBP", ProcessingDate: "17.10.2026"},
+			wantErr: ErrInvalidProcessingDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			err := getValidatePayment()(tt.payment)
+			if tt.wantErr == nil {
+				assert.NoErr(err)
+				return
+			}
+			assert.True(errors.Is(err, tt.wantErr))
+		})
+	}
+}
+
+func Test_getValidatePaymentAllErrors(t *testing.T) {
+	assert := is.New(t)
+
+	err := getValidatePayment()(&Payment{Amount: "abc", Currency: "GB", ProcessingDate: "today"})
+
+	var errs ValidationErrors
+	assert.True(errors.As(err, &errs))
+	assert.Equal(len(errs), 3)
+	assert.Equal(errs[0].Path, "data.attributes.currency")
+	assert.Equal(errs[1].Path, "data.attributes.amount")
+	assert.Equal(errs[2].Path, "data.attributes.processing_date")
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		minorUnits int64
+		currency   string
+		want       string
+		wantErr    error
+	}{
+		{name: "GBP", minorUnits: 10021, currency: "GBP", want: "100.21"},
+		{name: "GBP pence only", minorUnits: 5, currency: "GBP", want: "0.05"},
+		{name: "JPY", minorUnits: 1500, currency: "JPY", want: "1500"},
+		{name: "BHD", minorUnits: 1234, currency: "BHD", want: "1.234"},
+		{name: "unknown currency", minorUnits: 1, currency: "XYZ", wantErr: ErrInvalidCurrency},
+		{name: "negative", minorUnits: -1, currency: "GBP", wantErr: ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			got, err := FormatAmount(tt.minorUnits, tt.currency)
+			assert.True(errors.Is(err, tt.wantErr))
+			assert.Equal(got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package form3
+
+// String returns a pointer to the given string. Useful to set fields of a patch.
+func String(v string) *string {
+	return &v
+}
+
+// Bool returns a pointer to the given bool. Useful to set fields of a patch.
+func Bool(v bool) *bool {
+	return &v
+}
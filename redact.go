@@ -0,0 +1,168 @@
+package form3
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// RedactAction defines how a sensitive value is redacted before being logged.
+type RedactAction int
+
+// redact actions
+const (
+	// RedactMask replaces the value with asterisks. Only the last 4 characters of long values are kept.
+	// Use it for identifiers like account numbers, where the last characters help to tell them apart.
+	RedactMask RedactAction = iota
+	// RedactHash replaces the value with a hash of it. Allows correlating values without revealing them.
+	// Note: a hash of a value with few possible values (e.g. an account number) can be brute-forced.
+	RedactHash
+	// RedactDrop removes the value completely.
+	RedactDrop
+	// RedactReplace replaces the value completely with asterisks. Use it for names and other free text.
+	RedactReplace
+)
+
+const (
+	maskedValue       = "****"
+	maskKeepChars     = 4
+	maskMinLength     = 8
+	hashPrefix        = "sha256:"
+	hashKeepChars     = 16
+	nonJSONBody       = "[redacted: non-json body]"
+	filterParamPrefix = "filter["
+)
+
+// RedactionRules maps json attribute names to the action used to redact their values. The rules apply to
+// attributes on any level of the request and response bodies and to filter parameters in urls.
+type RedactionRules map[string]RedactAction
+
+// DefaultRedactionRules returns the rules used if no rules are set with WithRedaction.
+// They mask the personal information of account holders, of the parties of payments and of payee checks.
+func DefaultRedactionRules() RedactionRules {
+	return RedactionRules{
+		"name":                     RedactReplace,
+		"alternative_names":        RedactReplace,
+		"account_number":           RedactMask,
+		"iban":                     RedactMask,
+		"secondary_identification": RedactMask,
+		"private_identification":   RedactReplace,
+		"actors":                   RedactReplace,
+		"representative":           RedactReplace,
+		"account_name":             RedactReplace,
+		"address":                  RedactReplace,
+		"matched_name":             RedactReplace,
+	}
+}
+
+// redactBody applies the rules to a json body. Bodies that are not json are replaced completely
+// as it is unknown what they contain.
+func (s RedactionRules) redactBody(body []byte) string {
+	if len(s) == 0 || len(body) == 0 {
+		return string(body)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nonJSONBody
+	}
+	redacted, err := json.Marshal(s.redactValue(value))
+	if err != nil {
+		return nonJSONBody
+	}
+	return string(redacted)
+}
+
+func (s RedactionRules) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			action, ok := s[key]
+			switch {
+			case !ok:
+				v[key] = s.redactValue(item)
+			case action == RedactDrop:
+				delete(v, key)
+			default:
+				v[key] = redactAll(item, action)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = s.redactValue(item)
+		}
+	}
+	return value
+}
+
+// redactAll redacts all values contained in the given value.
+func redactAll(value interface{}, action RedactAction) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = redactAll(item, action)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactAll(item, action)
+		}
+		return v
+	case string:
+		return redactString(v, action)
+	case json.Number:
+		return redactString(v.String(), action)
+	}
+	// booleans and null don't reveal anything worth protecting
+	return value
+}
+
+func redactString(value string, action RedactAction) string {
+	if action == RedactHash {
+		hash := sha256.Sum256([]byte(value))
+		return hashPrefix + hex.EncodeToString(hash[:])[:hashKeepChars]
+	}
+
+	if action == RedactReplace || len(value) < maskMinLength {
+		return maskedValue
+	}
+	return maskedValue + value[len(value)-maskKeepChars:]
+}
+
+// redactURL applies the rules to the filter parameters of an url.
+func (s RedactionRules) redactURL(rawURL string) string {
+	if len(s) == 0 || !strings.Contains(rawURL, "?") {
+		return rawURL
+	}
+
+	uri, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	params := uri.Query()
+	for key, values := range params {
+		if !strings.HasPrefix(key, filterParamPrefix) {
+			continue
+		}
+		action, ok := s[strings.TrimSuffix(strings.TrimPrefix(key, filterParamPrefix), "]")]
+		if !ok {
+			continue
+		}
+		if action == RedactDrop {
+			params.Del(key)
+			continue
+		}
+		for i, value := range values {
+			values[i] = redactString(value, action)
+		}
+	}
+	uri.RawQuery = params.Encode()
+	return uri.String()
+}
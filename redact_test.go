@@ -0,0 +1,89 @@
+package form3
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRedactionRules_redactBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules RedactionRules
+		body  string
+		want  string
+	}{
+		{
+			name:  "default rules",
+			rules: DefaultRedactionRules(),
+			body: `{"data":{"id":"ad27e265","attributes":{"country":"GB","account_number":"41426819",` +
+				`"name":["Samantha Holder"],"secondary_identification":"A1B2","joint_account":false,"version":1}}}`,
+			want: `{"data":{"attributes":{"account_number":"****6819","country":"GB","joint_account":false,` +
+				`"name":["****"],"secondary_identification":"****","version":1},"id":"ad27e265"}}`,
+		},
+		{
+			name:  "hash and drop",
+			rules: RedactionRules{"iban": RedactHash, "name": RedactDrop},
+			body:  `{"data":[{"attributes":{"iban":"GB11NWBK40030041426819","name":["Sam"]}}]}`,
+			want:  `{"data":[{"attributes":{"iban":"sha256:634a39c8e6347107"}}]}`,
+		},
+		{
+			name:  "nested values",
+			rules: RedactionRules{"private_identification": RedactMask},
+			body:  `{"private_identification":{"birth_date":"2017-07-23","address":["10 Avenue des Champs"]}}`,
+			want:  `{"private_identification":{"address":["****amps"],"birth_date":"****7-23"}}`,
+		},
+		{
+			name:  "default rules replace personal information",
+			rules: DefaultRedactionRules(),
+			body: `{"private_identification":{"birth_date":"2017-07-23","address":["10 Avenue des Champs"]},` +
+				`"actors":[{"name":["Jeff Page"],"birth_date":"1970-01-01"}],"alternative_names":["Sam Holder"]}`,
+			want: `{"actors":[{"birth_date":"****","name":["****"]}],"alternative_names":["****"],` +
+				`"private_identification":{"address":["****"],"birth_date":"****"}}`,
+		},
+		{
+			name:  "default rules payment parties",
+			rules: DefaultRedactionRules(),
+			body: `{"beneficiary_party":{"account_name":"W Owens","account_number":"31926819",` +
+				`"address":["1 The Beneficiary Localtown SE2"],"bank_id":"403000","name":"Wilfred Jeremiah Owens"}}`,
+			want: `{"beneficiary_party":{"account_name":"****","account_number":"****6819","address":["****"],` +
+				`"bank_id":"403000","name":"****"}}`,
+		},
+		{
+			name:  "default rules payee check",
+			rules: DefaultRedactionRules(),
+			body: `{"data":{"type":"payee_checks","attributes":{"name":"Samantha Holder","account_number":"41426819",` +
+				`"bank_id":"400300","match_result":"close_match","matched_name":"Samantha Holden"}}}`,
+			want: `{"data":{"attributes":{"account_number":"****6819","bank_id":"400300",` +
+				`"match_result":"close_match","matched_name":"****","name":"****"},"type":"payee_checks"}}`,
+		},
+		{
+			name:  "non-json body",
+			rules: DefaultRedactionRules(),
+			body:  `account 41426819 not found`,
+			want:  nonJSONBody,
+		},
+		{
+			name:  "no rules",
+			rules: RedactionRules{},
+			body:  `{"name":["Samantha Holder"]}`,
+			want:  `{"name":["Samantha Holder"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+			assert.Equal(tt.rules.redactBody([]byte(tt.body)), tt.want)
+		})
+	}
+}
+
+func TestRedactionRules_redactURL(t *testing.T) {
+	assert := is.New(t)
+
+	rules := RedactionRules{"account_number": RedactMask, "iban": RedactDrop}
+	got := rules.redactURL("http://localhost/v1/organisation/accounts?filter%5Baccount_number%5D=41426819" +
+		"&filter%5Biban%5D=GB11NWBK40030041426819&filter%5Bcountry%5D=GB&page%5Bsize%5D=10")
+	assert.Equal(got, "http://localhost/v1/organisation/accounts?filter%5Baccount_number%5D=%2A%2A%2A%2A6819"+
+		"&filter%5Bcountry%5D=GB&page%5Bsize%5D=10")
+}
@@ -8,19 +8,43 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
-
-	"github.com/tehsphinx/dbg"
 )
 
 // custom errors for http status codes
 var (
-	ErrNotFound = errors.New("specified resource does not exist")
-	ErrConflict = errors.New("specified version incorrect")
+	ErrBadRequest   = errors.New("request was rejected by the server")
+	ErrUnauthorized = errors.New("request is not authenticated")
+	ErrForbidden    = errors.New("request is not permitted")
+	ErrNotFound     = errors.New("specified resource does not exist")
+	ErrConflict     = errors.New("specified version incorrect")
+	ErrRateLimited  = errors.New("too many requests")
+	ErrServer       = errors.New("server failed to process the request")
 )
 
 const (
 	typeAccounts attrType = "accounts"
+	typePayments attrType = "payments"
+
+	typePaymentSubmissions attrType = "payment_submissions"
+	typePaymentAdmissions  attrType = "payment_admissions"
+
+	typeReturns             attrType = "returns"
+	typeReturnSubmissions   attrType = "return_submissions"
+	typeReversals           attrType = "reversals"
+	typeReversalSubmissions attrType = "reversal_submissions"
+	typeRecalls             attrType = "recalls"
+	typeRecallSubmissions   attrType = "recall_submissions"
+
+	typeMandates             attrType = "mandates"
+	typeMandateSubmissions   attrType = "mandate_submissions"
+	typeDirectDebits         attrType = "directdebits"
+	typeDirectDebitReturns   attrType = "directdebit_returns"
+	typeDirectDebitReversals attrType = "directdebit_reversals"
+
+	typePayeeChecks attrType = "confirmation_of_payee"
 )
 
 type request struct {
@@ -30,7 +54,8 @@ type request struct {
 type requestData struct {
 	Type           attrType    `json:"type"`
 	ID             string      `json:"id"`
-	OrganisationID string      `json:"organisation_id"`
+	OrganisationID string      `json:"organisation_id,omitempty"`
+	Version        *int        `json:"version,omitempty"`
 	Attributes     interface{} `json:"attributes"`
 }
 
@@ -63,6 +88,7 @@ The function also tries to keep most of the logic in one place. This has pros an
 */
 func (s *Client) request(ctx context.Context, url string, options ...reqOption) error {
 	opts := applyReqestOptions(options)
+	opts.strictEnums = s.strictEnums
 
 	// marshal request body if request attributes are provided.
 	var body []byte
@@ -72,6 +98,7 @@ func (s *Client) request(ctx context.Context, url string, options ...reqOption)
 				Type:           opts.attrType,
 				ID:             opts.uid,
 				OrganisationID: opts.orgID,
+				Version:        opts.version,
 				Attributes:     opts.reqAttr,
 			},
 		}
@@ -83,35 +110,17 @@ func (s *Client) request(ctx context.Context, url string, options ...reqOption)
 		}
 	}
 
-	// build and execute request
-	if s.enableDbg {
-		dbg.Green(opts.method, url)
-		if len(body) != 0 {
-			dbg.Blue(string(body))
-		}
-	}
-	req, err := http.NewRequestWithContext(ctx, opts.method, url, bytes.NewReader(body))
-	if err != nil {
-		return fmt.Errorf("invalid request url: %w", err)
-	}
-	resp, err := s.client.Do(req)
+	// execute request. The max request timeout also bounds all retries of the request
+	ctx, cancel := context.WithTimeout(ctx, s.maxRequestTimeout)
+	defer cancel()
+
+	resp, body, err := s.do(ctx, opts, url, body)
 	if err != nil {
-		return fmt.Errorf("http request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != opts.statusOK {
-		err = errFromStatusCode(resp.StatusCode)
-		return fmt.Errorf("unexpected response status %d (%s): %w", resp.StatusCode, resp.Status, err)
-	}
-
-	// read and unmarshall response body
-	body, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("error reading response body: %w", err)
-	}
-	if s.enableDbg && len(body) != 0 {
-		dbg.Cyan(string(body))
+		return newAPIError(resp, body, s.redaction)
 	}
 
 	if opts.response != nil {
@@ -123,6 +132,93 @@ func (s *Client) request(ctx context.Context, url string, options ...reqOption)
 	return nil
 }
 
+// do executes the request and retries it according to the retry policy of the client.
+// The returned response body is already read and closed.
+func (s *Client) do(ctx context.Context, opts *reqOptions, url string, body []byte) (*http.Response, []byte, error) {
+	retry := s.retryPolicy != nil && isRetrySafe(opts)
+	var reauthenticated bool
+	for attempt := 1; ; attempt++ {
+		resp, respBody, err := s.doAttempt(ctx, attempt, opts.method, url, body)
+		if err == nil && !reauthenticated && s.invalidateToken(resp) {
+			// the request was rejected before being processed, so it can be sent again with a new token.
+			reauthenticated = true
+			resp, respBody, err = s.doAttempt(ctx, attempt, opts.method, url, body)
+		}
+		if !retry || attempt >= s.retryPolicy.MaxAttempts || ctx.Err() != nil ||
+			!s.retryPolicy.shouldRetry(resp, err) {
+			return resp, respBody, err
+		}
+
+		wait := s.retryPolicy.backoff(attempt, resp)
+		s.logRetry(opts.method, url, attempt, wait)
+		if !sleep(ctx, wait) {
+			return resp, respBody, err
+		}
+	}
+}
+
+// doAttempt executes the request once and logs the attempt.
+func (s *Client) doAttempt(ctx context.Context, attempt int, method, url string,
+	body []byte) (*http.Response, []byte, error) {
+	start := time.Now()
+	resp, respBody, err := s.doOnce(ctx, method, url, body)
+	s.logAttempt(LogEvent{
+		Method:   method,
+		URL:      url,
+		Duration: time.Since(start),
+		Attempt:  attempt,
+		Err:      err,
+	}, body, resp, respBody)
+	return resp, respBody, err
+}
+
+func (s *Client) doOnce(ctx context.Context, method, rawURL string, body []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid request url: %w", err)
+	}
+	if s.tokenSource != nil {
+		token, err := s.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("obtaining token failed: %w", err)
+		}
+		req.Header.Set("Authorization", bearerPrefix+token)
+	}
+	if s.signer != nil {
+		if err := s.signer.Sign(req, body); err != nil {
+			return nil, nil, err
+		}
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// the url.Error contains the url: it must not reveal sensitive filter values when logged.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = s.redaction.redactURL(urlErr.URL)
+		}
+		return nil, nil, fmt.Errorf("http request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// read response body
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	return resp, respBody, nil
+}
+
+// invalidateToken invalidates the token of a request rejected with 401 Unauthorized. It returns
+// true if the token was invalidated.
+func (s *Client) invalidateToken(resp *http.Response) bool {
+	if s.tokenSource == nil || resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	s.tokenSource.Invalidate(strings.TrimPrefix(resp.Request.Header.Get("Authorization"), bearerPrefix))
+	return true
+}
+
 func unmarshalResponse(body []byte, opts *reqOptions) error {
 	if err := json.Unmarshal(body, &opts.response); err != nil {
 		return fmt.Errorf("unmarshalling response failed: %w", err)
@@ -131,7 +227,7 @@ func unmarshalResponse(body []byte, opts *reqOptions) error {
 		return fmt.Errorf("unmarshalling response failed: %w", err)
 	}
 	opts.respAttr.fillFromResponse(opts.response.Data)
-	return nil
+	return checkEnums(opts.respAttr, opts)
 }
 
 func unmarshalListResponse(body []byte, opts *reqOptions) error {
@@ -145,17 +241,48 @@ func unmarshalListResponse(body []byte, opts *reqOptions) error {
 		}
 
 		dest.fillFromResponse(item)
+		if err := checkEnums(dest, opts); err != nil {
+			return err
+		}
 		opts.callback(dest)
 	}
+	if opts.links != nil {
+		for name, link := range opts.listResponse.Links {
+			opts.links[name] = link
+		}
+	}
+	return nil
+}
+
+// checkEnums rejects unknown values of closed value sets in strict mode.
+func checkEnums(dest responseFiller, opts *reqOptions) error {
+	checker, ok := dest.(enumChecker)
+	if !opts.strictEnums || !ok {
+		return nil
+	}
+	if err := checker.checkEnums(); err != nil {
+		return fmt.Errorf("unmarshalling response failed: %w", err)
+	}
 	return nil
 }
 
 func errFromStatusCode(statusCode int) error {
 	switch statusCode {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
 	case http.StatusNotFound:
 		return ErrNotFound
 	case http.StatusConflict:
 		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return ErrServer
 	}
 	return nil
 }
@@ -16,12 +16,15 @@ type reqOptions struct {
 	method       string
 	orgID        string
 	uid          string
+	version      *int
 	reqAttr      interface{}
 	response     *response
 	listResponse *listResponse
 	respAttr     responseFiller
 	factory      func() responseFiller
 	callback     func(responseFiller)
+	links        map[string]string
+	strictEnums  bool
 	statusOK     int
 	attrType     attrType
 }
@@ -64,6 +67,13 @@ func withUID(uid string) reqOptionFunc {
 	}
 }
 
+// withVersion adds the version of the data record to the request body.
+func withVersion(version int) reqOptionFunc {
+	return func(opts *reqOptions) {
+		opts.version = &version
+	}
+}
+
 // withReq adds the attributes part to the request body.
 func withReq(attributes interface{}) reqOptionFunc {
 	return func(opts *reqOptions) {
@@ -82,6 +92,11 @@ type responseFiller interface {
 	fillFromResponse(resp responseData)
 }
 
+// enumChecker is implemented by response types holding closed value sets. See WithStrictEnums.
+type enumChecker interface {
+	checkEnums() error
+}
+
 // withResp adds a pointer to be filled with the attributes part of the response body.
 func withResp(attributes responseFiller) reqOptionFunc {
 	return func(opts *reqOptions) {
@@ -102,3 +117,10 @@ func withListResp(factory func() responseFiller, cb func(responseFiller)) reqOpt
 		opts.callback = cb
 	}
 }
+
+// withLinks adds a map to be filled with the links part of the response body.
+func withLinks(links map[string]string) reqOptionFunc {
+	return func(opts *reqOptions) {
+		opts.links = links
+	}
+}
@@ -0,0 +1,152 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how requests failing with a transient error are retried.
+// See WithRetryPolicy and DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	MaxAttempts int
+	// BaseBackoff is the time waited after the first failed attempt. It doubles with every attempt.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the time waited between two attempts.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0 to 1) by which the backoff is randomly reduced to spread out retries.
+	Jitter float64
+	// RetryableStatus holds the http status codes a request is retried for.
+	RetryableStatus []int
+}
+
+// DefaultRetryPolicy returns a retry policy with 3 attempts retrying rate limited requests
+// and temporarily unavailable servers.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		Jitter:      0.2,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// shouldRetry checks if the result of an attempt is a transient failure worth retrying.
+func (s *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return isTransportError(err)
+	}
+	for _, status := range s.RetryableStatus {
+		if resp.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransportError checks if the error is a failure of the connection to the server (refused, reset,
+// timed out, ...), which is considered transient. Other errors like an untrusted certificate, errors of
+// transport middlewares or of the client itself (e.g. obtaining a token) and done contexts are not.
+func isTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrTokenRequest) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	// the http client wraps all errors in an url.Error, which implements net.Error itself
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(urlErr.Err, &netErr)
+}
+
+// backoff calculates the time to wait after the given failed attempt. A Retry-After header
+// sent by the server takes precedence over the calculated backoff.
+func (s *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return wait
+	}
+
+	wait := s.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if s.MaxBackoff > 0 && wait >= s.MaxBackoff {
+			break
+		}
+	}
+	if s.MaxBackoff > 0 && wait > s.MaxBackoff {
+		wait = s.MaxBackoff
+	}
+	if s.Jitter > 0 {
+		//nolint:gosec // jitter does not need a secure random source
+		wait -= time.Duration(rand.Float64() * s.Jitter * float64(wait))
+	}
+	return wait
+}
+
+// retryAfter reads the Retry-After header which can either hold seconds or a http date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// isRetrySafe checks if a request can be sent multiple times without side effects. Requests
+// with idempotent methods are safe. A POST is only safe if it carries a stable resource id,
+// so a retried create can never create a duplicate.
+func isRetrySafe(opts *reqOptions) bool {
+	switch opts.method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return opts.uid != ""
+	}
+	return false
+}
+
+// sleep waits for the given duration. It returns false if the context is done before.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
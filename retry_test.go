@@ -0,0 +1,250 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+const accountResponse = `{"data":{"type":"accounts","id":"some-id","version":1,"attributes":{"country":"GB"}}}`
+
+func TestClient_retry(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:     3,
+		BaseBackoff:     time.Millisecond,
+		MaxBackoff:      5 * time.Millisecond,
+		RetryableStatus: []int{http.StatusServiceUnavailable},
+	}
+
+	tests := []struct {
+		name         string
+		failures     int
+		failStatus   int
+		call         func(cl *Client) error
+		wantAttempts int32
+		wantErr      error
+	}{
+		{
+			name:       "fetch succeeds after transient failures",
+			failures:   2,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(cl *Client) error {
+				_, err := cl.FetchAccount(context.Background(), "some-id")
+				return err
+			},
+			wantAttempts: 3,
+		},
+		{
+			name:       "fetch gives up after max attempts",
+			failures:   5,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(cl *Client) error {
+				_, err := cl.FetchAccount(context.Background(), "some-id")
+				return err
+			},
+			wantAttempts: 3,
+			wantErr:      ErrServer,
+		},
+		{
+			name:       "status not retryable",
+			failures:   1,
+			failStatus: http.StatusBadRequest,
+			call: func(cl *Client) error {
+				_, err := cl.FetchAccount(context.Background(), "some-id")
+				return err
+			},
+			wantAttempts: 1,
+			wantErr:      ErrBadRequest,
+		},
+		{
+			name:       "patch is not retried",
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(cl *Client) error {
+				_, err := cl.UpdateAccount(context.Background(), "some-id", 0, &AccountPatch{})
+				return err
+			},
+			wantAttempts: 1,
+			wantErr:      ErrServer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			var attempts int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(atomic.AddInt32(&attempts, 1)) <= tt.failures {
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				_, _ = w.Write([]byte(accountResponse))
+			}))
+			defer srv.Close()
+
+			cl := NewClient(srv.URL, WithRetryPolicy(policy))
+			err := tt.call(cl)
+			assert.True(errors.Is(err, tt.wantErr))
+			assert.Equal(atomic.LoadInt32(&attempts), tt.wantAttempts)
+		})
+	}
+}
+
+func TestClient_retryAfterExceedsContext(t *testing.T) {
+	assert := is.New(t)
+
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	cl := NewClient(srv.URL, WithRetryPolicy(DefaultRetryPolicy()))
+	_, err := cl.FetchAccount(ctx, "some-id")
+	assert.True(errors.Is(err, ErrRateLimited))
+	assert.Equal(atomic.LoadInt32(&attempts), int32(1))
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "first attempt", attempt: 1, want: 100 * time.Millisecond},
+		{name: "third attempt", attempt: 3, want: 400 * time.Millisecond},
+		{name: "capped", attempt: 10, want: time.Second},
+		{name: "retry after seconds", attempt: 1, retryAfter: "3", want: 3 * time.Second},
+		{name: "invalid retry after", attempt: 2, retryAfter: "soon", want: 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			resp := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			assert.Equal(policy.backoff(tt.attempt, resp), tt.want)
+		})
+	}
+}
+
+func TestRetryPolicy_shouldRetry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	connRefused := &url.Error{Op: "Get", URL: "http://localhost", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}
+
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{name: "connection refused", err: fmt.Errorf("http request failed: %w", connRefused), want: true},
+		{
+			name: "context canceled",
+			err:  fmt.Errorf("http request failed: %w", &url.Error{Op: "Get", Err: context.Canceled}),
+		},
+		{
+			name: "context deadline exceeded",
+			err:  fmt.Errorf("http request failed: %w", &url.Error{Op: "Get", Err: context.DeadlineExceeded}),
+		},
+		{name: "token request failed", err: fmt.Errorf("obtaining token failed: %w", ErrTokenRequest)},
+		{
+			name: "middleware error",
+			err:  fmt.Errorf("http request failed: %w", &url.Error{Op: "Get", Err: errors.New("request not allowed")}),
+		},
+		{
+			name: "connection reset",
+			err:  fmt.Errorf("http request failed: %w", &url.Error{Op: "Get", Err: syscall.ECONNRESET}),
+			want: true,
+		},
+		{
+			name: "unexpected EOF",
+			err:  fmt.Errorf("http request failed: %w", &url.Error{Op: "Get", Err: io.ErrUnexpectedEOF}),
+			want: true,
+		},
+		{name: "signing failed", err: errors.New("signing request failed")},
+		{name: "retryable status", resp: &http.Response{StatusCode: http.StatusServiceUnavailable}, want: true},
+		{name: "status not retryable", resp: &http.Response{StatusCode: http.StatusBadRequest}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+			assert.Equal(policy.shouldRetry(tt.resp, tt.err), tt.want)
+		})
+	}
+}
+
+func TestClient_retryPermanentTransportErrors(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, attempts *int32) (string, []ClientOption)
+	}{
+		{
+			name: "untrusted certificate",
+			setup: func(t *testing.T, attempts *int32) (string, []ClientOption) {
+				srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+				srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+					if state == http.StateNew {
+						atomic.AddInt32(attempts, 1)
+					}
+				}
+				srv.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+				srv.StartTLS()
+				t.Cleanup(srv.Close)
+				return srv.URL, nil
+			},
+		},
+		{
+			name: "middleware error",
+			setup: func(t *testing.T, attempts *int32) (string, []ClientOption) {
+				middleware := func(http.RoundTripper) http.RoundTripper {
+					return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+						atomic.AddInt32(attempts, 1)
+						return nil, errors.New("request not allowed")
+					})
+				}
+				return "http://localhost", []ClientOption{WithTransportMiddleware(middleware)}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			var attempts int32
+			baseURL, options := tt.setup(t, &attempts)
+			cl := NewClient(baseURL, append(options, WithRetryPolicy(policy))...)
+			_, err := cl.FetchAccount(context.Background(), "some-id")
+			assert.True(err != nil)
+			assert.Equal(atomic.LoadInt32(&attempts), int32(1))
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTokenExpiryDelta = 30 * time.Second
+	// defaultTokenLifetime is assumed if the token server does not specify the lifetime of a token.
+	defaultTokenLifetime = time.Hour
+	bearerPrefix         = "Bearer "
+)
+
+// ErrTokenRequest is returned if a token could not be obtained from the token endpoint.
+var ErrTokenRequest = errors.New("token request failed")
+
+// TokenSource provides bearer tokens to authenticate requests. See WithTokenSource.
+type TokenSource interface {
+	// Token returns a valid access token.
+	Token(ctx context.Context) (string, error)
+	// Invalidate is called with a token the server rejected. The token must not be returned by Token again.
+	Invalidate(token string)
+}
+
+// ClientCredentials is a TokenSource implementing the OAuth2 client credentials grant. The token is
+// cached until shortly before it expires. It is safe for concurrent use.
+type ClientCredentials struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	client       *http.Client
+	// tokens are refreshed this long before they expire
+	expiryDelta time.Duration
+	now         func() time.Time
+
+	// refresh holds a value while a token is fetched, so concurrent callers wait for a single fetch.
+	// Unlike a mutex the waiting callers can give up when their context is done.
+	refresh chan struct{}
+
+	m      sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewClientCredentials creates a TokenSource fetching tokens from the given token url using the OAuth2
+// client credentials grant.
+func NewClientCredentials(tokenURL, clientID, clientSecret string, scopes ...string) *ClientCredentials {
+	return &ClientCredentials{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+		client:       &http.Client{Timeout: defaultRequestTimeout},
+		expiryDelta:  defaultTokenExpiryDelta,
+		now:          time.Now,
+		refresh:      make(chan struct{}, 1),
+	}
+}
+
+// Token returns the cached token or fetches a new one if it is (about to be) expired. Callers
+// waiting for the fetch of another caller return the error of their context if it is done first.
+func (s *ClientCredentials) Token(ctx context.Context) (string, error) {
+	if token, ok := s.cached(); ok {
+		return token, nil
+	}
+
+	select {
+	case s.refresh <- struct{}{}:
+		defer func() { <-s.refresh }()
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	// the token might have been refreshed while waiting
+	if token, ok := s.cached(); ok {
+		return token, nil
+	}
+
+	token, expiresIn, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.token = token
+	s.expiry = s.now().Add(s.lifetime(expiresIn))
+	return token, nil
+}
+
+// cached returns the cached token if it is still valid.
+func (s *ClientCredentials) cached() (string, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.token, s.token != "" && s.now().Before(s.expiry)
+}
+
+// lifetime returns how long a token expiring in given duration is cached. Tokens are refreshed expiryDelta
+// before they expire, but not before half of their lifetime has passed, so short-lived tokens are reused too.
+func (s *ClientCredentials) lifetime(expiresIn time.Duration) time.Duration {
+	if expiresIn <= 0 {
+		expiresIn = defaultTokenLifetime
+	}
+	if lifetime := expiresIn - s.expiryDelta; lifetime > expiresIn/2 {
+		return lifetime
+	}
+	return expiresIn / 2
+}
+
+// Invalidate drops the cached token if it is the given one.
+func (s *ClientCredentials) Invalidate(token string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func (s *ClientCredentials) fetch(ctx context.Context) (string, time.Duration, error) {
+	params := url.Values{}
+	params.Set("grant_type", "client_credentials")
+	if len(s.scopes) != 0 {
+		params.Set("scope", strings.Join(s.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid token url: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(s.clientID), url.QueryEscape(s.clientSecret))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %v", ErrTokenRequest, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: error reading response body: %v", ErrTokenRequest, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("%w: unexpected response status %d (%s): %s",
+			ErrTokenRequest, resp.StatusCode, resp.Status, body)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", 0, fmt.Errorf("%w: unmarshalling response failed: %v", ErrTokenRequest, err)
+	}
+	if token.AccessToken == "" {
+		return "", 0, fmt.Errorf("%w: response contains no access token", ErrTokenRequest)
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return "", 0, fmt.Errorf("%w: unsupported token type %q", ErrTokenRequest, token.TokenType)
+	}
+	return token.AccessToken, time.Duration(token.ExpiresIn) * time.Second, nil
+}
@@ -0,0 +1,166 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+// tokenServer issues the tokens token-1, token-2, ... valid for expiresIn seconds.
+func tokenServer(t *testing.T, expiresIn int, fetches *int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, secret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		no := atomic.AddInt32(fetches, 1)
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, no, expiresIn)
+	}))
+}
+
+func TestClientCredentials_Token(t *testing.T) {
+	assert := is.New(t)
+
+	var fetches int32
+	srv := tokenServer(t, 3600, &fetches)
+	defer srv.Close()
+
+	now := time.Now()
+	source := NewClientCredentials(srv.URL, "client", "secret", "accounts")
+	source.now = func() time.Time { return now }
+
+	// concurrent callers share a single fetch
+	var wg sync.WaitGroup
+	tokens := make([]string, 10)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], _ = source.Token(context.Background())
+		}(i)
+	}
+	wg.Wait()
+	for _, token := range tokens {
+		assert.Equal(token, "token-1")
+	}
+	assert.Equal(atomic.LoadInt32(&fetches), int32(1))
+
+	// shortly before expiry the token is refreshed
+	now = now.Add(3600*time.Second - defaultTokenExpiryDelta)
+	token, err := source.Token(context.Background())
+	assert.NoErr(err)
+	assert.Equal(token, "token-2")
+
+	// an invalidated token is refreshed
+	source.Invalidate("token-2")
+	token, err = source.Token(context.Background())
+	assert.NoErr(err)
+	assert.Equal(token, "token-3")
+}
+
+func TestClientCredentials_lifetime(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn time.Duration
+		want      time.Duration
+	}{
+		{name: "refreshed before expiry", expiresIn: time.Hour, want: time.Hour - defaultTokenExpiryDelta},
+		{name: "short lived", expiresIn: 30 * time.Second, want: 15 * time.Second},
+		{name: "shorter than expiry delta", expiresIn: 10 * time.Second, want: 5 * time.Second},
+		{name: "unspecified", expiresIn: 0, want: defaultTokenLifetime - defaultTokenExpiryDelta},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := is.New(t)
+
+			source := NewClientCredentials("http://localhost", "client", "secret")
+			assert.Equal(source.lifetime(tt.expiresIn), tt.want)
+		})
+	}
+}
+
+func TestClientCredentials_TokenShortLived(t *testing.T) {
+	assert := is.New(t)
+
+	var fetches int32
+	srv := tokenServer(t, 0, &fetches)
+	defer srv.Close()
+
+	source := NewClientCredentials(srv.URL, "client", "secret")
+	for i := 0; i < 3; i++ {
+		token, err := source.Token(context.Background())
+		assert.NoErr(err)
+		assert.Equal(token, "token-1")
+	}
+	assert.Equal(atomic.LoadInt32(&fetches), int32(1))
+}
+
+func TestClientCredentials_TokenWaitCanceled(t *testing.T) {
+	assert := is.New(t)
+
+	source := NewClientCredentials("http://localhost", "client", "secret")
+	// another caller is fetching a token
+	source.refresh <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := source.Token(ctx)
+	assert.True(errors.Is(err, context.Canceled))
+}
+
+func TestClientCredentials_TokenError(t *testing.T) {
+	assert := is.New(t)
+
+	var fetches int32
+	srv := tokenServer(t, 3600, &fetches)
+	defer srv.Close()
+
+	source := NewClientCredentials(srv.URL, "client", "wrong secret")
+	_, err := source.Token(context.Background())
+	assert.True(errors.Is(err, ErrTokenRequest))
+}
+
+func TestClient_WithTokenSource(t *testing.T) {
+	assert := is.New(t)
+
+	var fetches int32
+	tokenSrv := tokenServer(t, 3600, &fetches)
+	defer tokenSrv.Close()
+
+	// the api server rejects the first token to force a refresh
+	var requests int32
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(accountResponse))
+	}))
+	defer apiSrv.Close()
+
+	cl := NewClient(apiSrv.URL, WithTokenSource(NewClientCredentials(tokenSrv.URL, "client", "secret")))
+	account, err := cl.FetchAccount(context.Background(), "some-id")
+	assert.NoErr(err)
+	assert.Equal(account.ID(), "some-id")
+	assert.Equal(atomic.LoadInt32(&requests), int32(2))
+	assert.Equal(atomic.LoadInt32(&fetches), int32(2))
+
+	// a second rejection is not retried again
+	atomic.StoreInt32(&fetches, 10)
+	cl = NewClient(apiSrv.URL, WithTokenSource(NewClientCredentials(tokenSrv.URL, "client", "secret")))
+	_, err = cl.FetchAccount(context.Background(), "some-id")
+	assert.True(errors.Is(err, ErrUnauthorized))
+}
@@ -0,0 +1,108 @@
+package form3
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError describes one invalid attribute found by the client side validation.
+// Check for the violated rule with e.g. `errors.Is(err, form3.ErrInvalidBankID)`.
+type ValidationError struct {
+	// Field is the name of the invalid struct field (e.g. BankID).
+	Field string
+	// Path is the json path of the attribute in the request body (e.g. data.attributes.bank_id).
+	Path string
+	// Rule describes the violated rule.
+	Rule string
+	// Value is the offending value. It is not part of the error message, as it can hold personal
+	// information (e.g. an IBAN) and errors are commonly logged.
+	Value string
+
+	// err holds the validation error of the field (e.g. ErrInvalidBankID or a *CountryRuleError).
+	err error
+}
+
+// Error implements the error interface.
+func (s *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", s.Path, s.Rule)
+}
+
+// Unwrap returns the validation error of the field (e.g. ErrInvalidBankID).
+func (s *ValidationError) Unwrap() error {
+	return s.err
+}
+
+// ValidationErrors lists all invalid attributes of a resource. It matches every error contained
+// in it with errors.Is and errors.As, so `errors.Is(err, form3.ErrInvalidCountry)` reports if the
+// country is one of the invalid attributes.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface.
+func (s ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(s))
+	for _, err := range s {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports if any of the contained errors matches the target.
+func (s ValidationErrors) Is(target error) bool {
+	for _, err := range s {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first contained error that matches the target and sets the target to it.
+func (s ValidationErrors) As(target interface{}) bool {
+	for _, err := range s {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// err returns the list as error or nil if it is empty.
+func (s ValidationErrors) err() error {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+// fieldPath is the location of an attribute used to report validation errors.
+type fieldPath struct {
+	field string
+	path  string
+}
+
+// attributesPath is the json path of the attributes in a request body.
+const attributesPath = "data.attributes."
+
+func attrPath(field, jsonName string) fieldPath {
+	return fieldPath{field: field, path: attributesPath + jsonName}
+}
+
+// add appends a validation error for the given attribute.
+func (s *ValidationErrors) add(path fieldPath, value string, err error) {
+	*s = append(*s, &ValidationError{
+		Field: path.field,
+		Path:  path.path,
+		Rule:  ruleOf(err),
+		Value: value,
+		err:   err,
+	})
+}
+
+func ruleOf(err error) string {
+	var ruleErr *CountryRuleError
+	if errors.As(err, &ruleErr) {
+		return ruleErr.Error()
+	}
+	return err.Error()
+}